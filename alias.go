@@ -0,0 +1,60 @@
+package gover
+
+import "fmt"
+
+// ResolveAlias returns the version in releases named by one of the
+// well-known aliases used by Go installers and CI configurations:
+//
+//   - "stable" is the newest release, not counting prereleases.
+//   - "oldstable" is the newest release of the Go language version
+//     before that of "stable".
+//   - "latest" is the newest version, including prereleases.
+//   - "tip" is the language version under development, the one following
+//     that of "latest". Because tip is not a release, ResolveAlias returns
+//     only its language version, such as "go1.25".
+//
+// For example, if the newest releases are go1.23.4, go1.22.10,
+// and go1.24rc1, then "stable" is "go1.23.4", "oldstable" is "go1.22.10",
+// "latest" is "go1.24rc1", and "tip" is "go1.25".
+//
+// Invalid entries in releases are ignored. ResolveAlias returns an error
+// if alias is not one of these names or releases contains no version it names.
+func ResolveAlias(alias string, releases []string) (string, error) {
+	var stable, latest string
+	for _, r := range releases {
+		if !IsValid(r) {
+			continue
+		}
+		if latest == "" || Compare(r, latest) > 0 {
+			latest = r
+		}
+		if IsRelease(r) && (stable == "" || Compare(r, stable) > 0) {
+			stable = r
+		}
+	}
+	var v string
+	switch alias {
+	case "stable":
+		v = stable
+	case "latest":
+		v = latest
+	case "tip":
+		v = NextLang(latest)
+	case "oldstable":
+		if stable == "" {
+			break
+		}
+		lang := Lang(stable)
+		for _, r := range releases {
+			if IsRelease(r) && Compare(r, lang) < 0 && (v == "" || Compare(r, v) > 0) {
+				v = r
+			}
+		}
+	default:
+		return "", fmt.Errorf("unknown version alias %q", alias)
+	}
+	if v == "" {
+		return "", fmt.Errorf("no release for version alias %q", alias)
+	}
+	return v, nil
+}
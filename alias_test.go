@@ -0,0 +1,39 @@
+package gover
+
+import "testing"
+
+var aliasReleases = []string{
+	"go1.22.9", "go1.23.3", "go1.24rc1", "go1.22.10", "bad", "go1.23.4", "go1.23rc2",
+}
+
+var resolveAliasTests = []struct {
+	alias    string
+	releases []string
+	out      string
+	err      bool
+}{
+	{"stable", aliasReleases, "go1.23.4", false},
+	{"oldstable", aliasReleases, "go1.22.10", false},
+	{"latest", aliasReleases, "go1.24rc1", false},
+	{"tip", aliasReleases, "go1.25", false},
+	{"latest", []string{"go1.20.5", "go1.21.0"}, "go1.21.0", false},
+	{"tip", []string{"go1.20.5", "go1.21rc2"}, "go1.22", false},
+	{"tip", []string{"go1.19.5", "go1.20rc1"}, "go1.21", false},
+	{"oldstable", []string{"go1.20.5", "go1.20.4", "go1.19.13", "go1.19rc1"}, "go1.19.13", false},
+	{"oldstable", []string{"go1.20", "go1.20.1", "go1.19"}, "go1.19", false},
+	{"oldstable", []string{"go1.21.0", "go1.21.1", "go1.21rc2"}, "", true},
+	{"stable", []string{"go1.24rc1"}, "", true},
+	{"latest", nil, "", true},
+	{"tip", nil, "", true},
+	{"Stable", aliasReleases, "", true},
+	{"newest", aliasReleases, "", true},
+}
+
+func TestResolveAlias(t *testing.T) {
+	for _, tt := range resolveAliasTests {
+		out, err := ResolveAlias(tt.alias, tt.releases)
+		if out != tt.out || (err != nil) != tt.err {
+			t.Errorf("ResolveAlias(%q, %q) = %q, %v, want %q, err=%v", tt.alias, tt.releases, out, err, tt.out, tt.err)
+		}
+	}
+}
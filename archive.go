@@ -0,0 +1,156 @@
+package gover
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Kinds of files published on the download server, as reported in
+// the Kind field of a File.
+const (
+	KindArchive   = "archive"   // a binary distribution, such as go1.21.3.linux-amd64.tar.gz
+	KindInstaller = "installer" // an installer, such as go1.21.3.windows-amd64.msi
+	KindSource    = "source"    // the source distribution, such as go1.21.3.src.tar.gz
+)
+
+// Go14BootstrapArchive is the name of the source archive of the Go 1.4
+// bootstrap toolchain, the last one that can be built with a C compiler,
+// for building Go 1.5 through Go 1.19 from source. Unlike other files, it is published
+// at https://dl.google.com/go/ and is not listed by the download server.
+const Go14BootstrapArchive = "go1.4-bootstrap-20171003.tar.gz"
+
+// FileName returns the name of the file of the given kind that the
+// download server publishes for Go version v on the system goos/goarch.
+// For example:
+//
+//	FileName("go1.21.3", "linux", "amd64", KindArchive) = "go1.21.3.linux-amd64.tar.gz"
+//	FileName("go1.21.3", "windows", "amd64", KindArchive) = "go1.21.3.windows-amd64.zip"
+//	FileName("go1.21.3", "linux", "arm", KindArchive) = "go1.21.3.linux-armv6l.tar.gz"
+//	FileName("go1.21.3", "darwin", "arm64", KindInstaller) = "go1.21.3.darwin-arm64.pkg"
+//	FileName("go1.21.3", "", "", KindSource) = "go1.21.3.src.tar.gz"
+//
+// Versions are named canonically, so the archives of go1.20.0 are named "go1.20".
+// FileName returns an error if v is invalid, a goos or goarch is missing,
+// or there are no files of that kind for goos, as for installers on Linux.
+func FileName(v, goos, goarch, kind string) (string, error) {
+	if !IsValid(v) {
+		return "", fmt.Errorf("invalid version %q", v)
+	}
+	name := MustParse(v).Canonical()
+	if kind == KindSource {
+		return name + ".src.tar.gz", nil
+	}
+	if goos == "" || goarch == "" {
+		return "", fmt.Errorf("missing GOOS or GOARCH for %s %s", name, kind)
+	}
+	if goos == "linux" && goarch == "arm" {
+		goarch = "armv6l" // built for ARMv6, and so runs on later processors
+	}
+	name += "." + goos + "-" + goarch
+	switch kind {
+	case KindArchive:
+		if goos == "windows" {
+			return name + ".zip", nil
+		}
+		return name + ".tar.gz", nil
+	case KindInstaller:
+		switch goos {
+		case "windows":
+			return name + ".msi", nil
+		case "darwin":
+			return name + ".pkg", nil
+		}
+		return "", fmt.Errorf("no installer for %s", goos)
+	}
+	return "", fmt.Errorf("unknown file kind %q", kind)
+}
+
+// ArchiveName returns the name of the binary distribution archive
+// of Go version v for goos/goarch, as by FileName with KindArchive.
+func ArchiveName(v, goos, goarch string) (string, error) {
+	return FileName(v, goos, goarch, KindArchive)
+}
+
+// DownloadURL returns the URL from which the binary distribution archive
+// of Go version v for goos/goarch is downloaded, using the download server
+// configured for the zero Client, which honors the GOVER_MIRROR
+// environment variable. Use [Client.DownloadURL] to choose another server.
+func DownloadURL(v, goos, goarch string) (string, error) {
+	return new(Client).DownloadURL(v, goos, goarch)
+}
+
+// DownloadURL returns the URL from which the binary distribution archive
+// of Go version v for goos/goarch is downloaded from c's download server.
+func (c *Client) DownloadURL(v, goos, goarch string) (string, error) {
+	name, err := ArchiveName(v, goos, goarch)
+	if err != nil {
+		return "", err
+	}
+	return c.URL(name), nil
+}
+
+// File returns the description of the named file, such as
+// "go1.21.3.linux-amd64.tar.gz", as listed by the download server,
+// including its SHA-256 checksum and size.
+func (c *Client) File(ctx context.Context, filename string) (File, error) {
+	list, err := c.List(ctx)
+	if err != nil {
+		return File{}, err
+	}
+	for _, r := range list {
+		for _, f := range r.Files {
+			if f.Filename == filename {
+				return f, nil
+			}
+		}
+	}
+	return File{}, fmt.Errorf("file %s not listed by download server", filename)
+}
+
+// VerifyArchive checks that the file at path is the binary distribution
+// archive of Go version v for goos/goarch, by comparing its SHA-256 checksum
+// with the one listed by the download server configured for the zero Client.
+// Use [Client.VerifyArchive] to choose another server or a context.
+func VerifyArchive(path string, v, goos, goarch string) error {
+	return new(Client).VerifyArchive(context.Background(), path, v, goos, goarch)
+}
+
+// VerifyArchive checks that the file at path is the binary distribution
+// archive of Go version v for goos/goarch, by comparing its SHA-256 checksum
+// with the one listed by c's download server.
+func (c *Client) VerifyArchive(ctx context.Context, path string, v, goos, goarch string) error {
+	name, err := ArchiveName(v, goos, goarch)
+	if err != nil {
+		return err
+	}
+	f, err := c.File(ctx, name)
+	if err != nil {
+		return err
+	}
+	return verifyFile(path, f.SHA256)
+}
+
+// verifyFile checks that the file at path has the hex-encoded SHA-256 checksum sum.
+func verifyFile(path, sum string) error {
+	if sum == "" {
+		return fmt.Errorf("verifying %s: no checksum available", path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("verifying %s: %v", path, err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, sum) {
+		return fmt.Errorf("verifying %s: checksum mismatch: have %s, want %s", path, got, sum)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package gover
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var fileNameTests = []struct {
+	v, goos, goarch, kind string
+	out                   string
+}{
+	{"go1.21.3", "linux", "amd64", KindArchive, "go1.21.3.linux-amd64.tar.gz"},
+	{"go1.21.3", "windows", "amd64", KindArchive, "go1.21.3.windows-amd64.zip"},
+	{"go1.21.3", "darwin", "arm64", KindArchive, "go1.21.3.darwin-arm64.tar.gz"},
+	{"go1.21.3", "linux", "arm", KindArchive, "go1.21.3.linux-armv6l.tar.gz"},
+	{"go1.21.3", "freebsd", "arm", KindArchive, "go1.21.3.freebsd-arm.tar.gz"},
+	{"go1.22rc1", "linux", "arm64", KindArchive, "go1.22rc1.linux-arm64.tar.gz"},
+	{"go1.20.0", "linux", "386", KindArchive, "go1.20.linux-386.tar.gz"},
+	{"go1", "linux", "amd64", KindArchive, "go1.linux-amd64.tar.gz"},
+	{"go1.21.3", "windows", "386", KindInstaller, "go1.21.3.windows-386.msi"},
+	{"go1.21.3", "darwin", "amd64", KindInstaller, "go1.21.3.darwin-amd64.pkg"},
+	{"go1.21.3", "linux", "amd64", KindInstaller, ""},
+	{"go1.21.3", "", "", KindSource, "go1.21.3.src.tar.gz"},
+	{"go1.21.3", "linux", "amd64", KindSource, "go1.21.3.src.tar.gz"},
+	{"go1.21.3", "linux", "", KindArchive, ""},
+	{"go1.21.3", "linux", "amd64", "tarball", ""},
+	{"1.21.3", "linux", "amd64", KindArchive, ""},
+}
+
+func TestFileName(t *testing.T) {
+	for _, tt := range fileNameTests {
+		out, err := FileName(tt.v, tt.goos, tt.goarch, tt.kind)
+		if out != tt.out || (err != nil) != (tt.out == "") {
+			t.Errorf("FileName(%q, %q, %q, %q) = %q, %v, want %q", tt.v, tt.goos, tt.goarch, tt.kind, out, err, tt.out)
+		}
+	}
+}
+
+func TestDownloadURL(t *testing.T) {
+	t.Setenv("GOVER_MIRROR", "")
+	if u, err := DownloadURL("go1.21.3", "linux", "amd64"); u != "https://go.dev/dl/go1.21.3.linux-amd64.tar.gz" || err != nil {
+		t.Errorf("DownloadURL(go1.21.3, linux, amd64) = %q, %v", u, err)
+	}
+	c := &Client{BaseURL: "https://golang.google.cn/dl/"}
+	if u, err := c.DownloadURL("go1.21.3", "windows", "arm64"); u != "https://golang.google.cn/dl/go1.21.3.windows-arm64.zip" || err != nil {
+		t.Errorf("Client.DownloadURL(go1.21.3, windows, arm64) = %q, %v", u, err)
+	}
+	if u, err := DownloadURL("go1.21.x", "linux", "amd64"); err == nil {
+		t.Errorf("DownloadURL(go1.21.x, linux, amd64) = %q, nil, want error", u)
+	}
+}
+
+func TestVerifyArchive(t *testing.T) {
+	data := []byte("not really a Go distribution\n")
+	sum := sha256.Sum256(data)
+	index := `[{"version": "go1.21.3", "stable": true, "files": [
+		{"filename": "go1.21.3.linux-amd64.tar.gz", "os": "linux", "arch": "amd64", "version": "go1.21.3",
+		"sha256": "` + hex.EncodeToString(sum[:]) + `", "size": 29, "kind": "archive"},
+		{"filename": "go1.21.3.linux-386.tar.gz", "os": "linux", "arch": "386", "version": "go1.21.3",
+		"sha256": "0000000000000000000000000000000000000000000000000000000000000000", "size": 29, "kind": "archive"}
+	]}]`
+	c := newTestClient(t, index)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "go.tar.gz")
+	if err := os.WriteFile(path, data, 0o666); err != nil {
+		t.Fatal(err)
+	}
+
+	if f, err := c.File(ctx, "go1.21.3.linux-amd64.tar.gz"); err != nil || f.Size != 29 || f.Kind != KindArchive {
+		t.Errorf("File(go1.21.3.linux-amd64.tar.gz) = %+v, %v", f, err)
+	}
+	if err := c.VerifyArchive(ctx, path, "go1.21.3", "linux", "amd64"); err != nil {
+		t.Errorf("VerifyArchive(linux/amd64): %v", err)
+	}
+	if err := c.VerifyArchive(ctx, path, "go1.21.3", "linux", "386"); err == nil || !strings.Contains(err.Error(), "mismatch") {
+		t.Errorf("VerifyArchive(linux/386) = %v, want checksum mismatch", err)
+	}
+	if err := c.VerifyArchive(ctx, path, "go1.21.3", "linux", "arm64"); err == nil {
+		t.Errorf("VerifyArchive(linux/arm64) = nil, want error for unlisted file")
+	}
+	if err := c.VerifyArchive(ctx, filepath.Join(t.TempDir(), "missing"), "go1.21.3", "linux", "amd64"); err == nil {
+		t.Errorf("VerifyArchive(missing file) = nil, want error")
+	}
+}
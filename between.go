@@ -0,0 +1,59 @@
+package gover
+
+// A BoundOption changes how Between treats its bounds.
+// Options can be combined with the | operator.
+type BoundOption int
+
+const (
+	ExcludeLo BoundOption = 1 << iota // versions equal to lo are not between lo and hi
+	ExcludeHi                         // versions equal to hi are not between lo and hi
+
+	ExcludeBoth = ExcludeLo | ExcludeHi
+)
+
+// Between reports whether lo ≤ v ≤ hi, interpreted as Go versions
+// and compared using Compare.
+// By default both bounds are inclusive; the options ExcludeLo and ExcludeHi
+// make the corresponding bound exclusive, so that, for example,
+//
+//	Between(v, "go1.21", "go1.22", ExcludeHi)
+//
+// reports whether v is the go1.21 language version or belongs to its release series.
+// An empty lo or hi leaves the range unbounded on that side.
+// Between reports false if v is invalid, or if lo or hi is neither empty nor valid.
+func Between(v, lo, hi string, opts ...BoundOption) bool {
+	var opt BoundOption
+	for _, o := range opts {
+		opt |= o
+	}
+	if !IsValid(v) || lo != "" && !IsValid(lo) || hi != "" && !IsValid(hi) {
+		return false
+	}
+	if lo != "" {
+		if c := Compare(v, lo); c < 0 || c == 0 && opt&ExcludeLo != 0 {
+			return false
+		}
+	}
+	if hi != "" {
+		if c := Compare(v, hi); c > 0 || c == 0 && opt&ExcludeHi != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clamp returns v limited to the range [lo, hi]: lo if v compares less than lo,
+// hi if v compares greater than hi, and v otherwise.
+// An empty lo or hi leaves the range unbounded on that side.
+// Because invalid versions compare less than valid ones,
+// Clamp returns lo for an invalid v.
+// The result is unspecified if lo compares greater than hi.
+func Clamp(v, lo, hi string) string {
+	if lo != "" && Compare(v, lo) < 0 {
+		return lo
+	}
+	if hi != "" && Compare(v, hi) > 0 {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,68 @@
+package gover
+
+import "testing"
+
+var betweenTests = []struct {
+	v, lo, hi string
+	opt       BoundOption
+	out       bool
+}{
+	{"go1.21.3", "go1.21", "go1.22", 0, true},
+	{"go1.21", "go1.21", "go1.22", 0, true},
+	{"go1.22", "go1.21", "go1.22", 0, true},
+	{"go1.21", "go1.21", "go1.22", ExcludeLo, false},
+	{"go1.21rc1", "go1.21", "go1.22", ExcludeLo, true},
+	{"go1.22", "go1.21", "go1.22", ExcludeHi, false},
+	{"go1.22rc1", "go1.21", "go1.22", ExcludeHi, false},
+	{"go1.21.99", "go1.21", "go1.22", ExcludeHi, true},
+	{"go1.21", "go1.21", "go1.22", ExcludeBoth, false},
+	{"go1.22", "go1.21", "go1.22", ExcludeBoth, false},
+	{"go1.21.5", "go1.21", "go1.22", ExcludeBoth, true},
+	{"go1.20", "go1.20.0", "go1.20.0", 0, true},
+	{"go1.20", "go1.20.0", "go1.20.0", ExcludeHi, false},
+	{"go1.19", "go1.21", "go1.22", 0, false},
+	{"go1.23", "go1.21", "go1.22", 0, false},
+	{"go1.23", "go1.21", "", 0, true},
+	{"go1.2", "", "go1.21", ExcludeBoth, true},
+	{"go1.2", "", "", 0, true},
+	{"bad", "", "", 0, false},
+	{"go1.21.3", "1.21", "go1.22", 0, false},
+	{"go1.21.3", "go1.21", "bad", 0, false},
+}
+
+func TestBetween(t *testing.T) {
+	for _, tt := range betweenTests {
+		if out := Between(tt.v, tt.lo, tt.hi, tt.opt); out != tt.out {
+			t.Errorf("Between(%q, %q, %q, %d) = %v, want %v", tt.v, tt.lo, tt.hi, tt.opt, out, tt.out)
+		}
+	}
+	if !Between("go1.21.3", "go1.21.3", "go1.21.3") {
+		t.Errorf("Between without options excludes bounds")
+	}
+	if Between("go1.21.3", "go1.21.3", "go1.22", ExcludeHi, ExcludeLo) {
+		t.Errorf("Between with separate options includes excluded bound")
+	}
+}
+
+var clampTests = []struct {
+	v, lo, hi string
+	out       string
+}{
+	{"go1.21.3", "go1.21.0", "go1.22.5", "go1.21.3"},
+	{"go1.20.14", "go1.21.0", "go1.22.5", "go1.21.0"},
+	{"go1.23.0", "go1.21.0", "go1.22.5", "go1.22.5"},
+	{"go1.22rc1", "go1.21.0", "go1.22", "go1.22"},
+	{"go1.21.0", "go1.21.0", "go1.22.5", "go1.21.0"},
+	{"go1.20", "go1.20.0", "go1.22.5", "go1.20"},
+	{"go1.30.0", "go1.21.0", "", "go1.30.0"},
+	{"go1.2", "", "go1.21.0", "go1.2"},
+	{"bad", "go1.21.0", "go1.22.5", "go1.21.0"},
+}
+
+func TestClamp(t *testing.T) {
+	for _, tt := range clampTests {
+		if out := Clamp(tt.v, tt.lo, tt.hi); out != tt.out {
+			t.Errorf("Clamp(%q, %q, %q) = %q, want %q", tt.v, tt.lo, tt.hi, out, tt.out)
+		}
+	}
+}
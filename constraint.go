@@ -0,0 +1,584 @@
+package gover
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// A Constraint describes a set of Go versions, such as the versions
+// that are at least go1.21 and less than go1.24.
+// The zero Constraint matches no versions.
+type Constraint struct {
+	text   string
+	ranges []interval
+}
+
+// ParseConstraint parses a constraint, which is a boolean expression of
+// terms that a version must satisfy, such as ">=go1.21, <go1.24" or
+// "(>=go1.21 && <go1.22) || >=go1.23.2".
+// Terms are combined with && (and), || (or), and parentheses;
+// && takes precedence over ||, and a comma is the same as &&.
+//
+// Most terms are comparisons: a version preceded by one of the operators
+// <, <=, >, >=, or =. A version without an operator must match exactly.
+// Versions are compared using Compare, so, for example, ">=go1.21" matches
+// the language version go1.21, its prereleases such as go1.21rc1,
+// and its releases such as go1.21.0.
+//
+// The operators ^ and ~ also match later versions, as in other version
+// constraint languages: "^go1.21.3" matches go1.21.3 and any later Go 1 version,
+// while "~go1.21.3" matches go1.21.3 and any later version in the go1.21 series.
+// The series is that of the language version, so "~go1.21" and "~go1.21rc1"
+// include the go1.21 release candidates and releases but not go1.22rc1,
+// and, because before Go 1.21 a series' release candidates preceded its
+// first release "go1.N", "~go1.20" does not include go1.20rc1.
+//
+// A term can also be a wildcard pattern, without an operator:
+// "go1.21.x" matches go1.21.0 and all later versions in the go1.21 series,
+// "go1.x" matches go1 and all later versions before Go 2,
+// and "*" matches any version. A "*" may be used in place of the "x".
+func ParseConstraint(s string) (Constraint, error) {
+	p := &constraintParser{text: s, toks: tokenizeConstraint(s)}
+	if len(p.toks) == 0 {
+		return Constraint{}, fmt.Errorf("empty constraint")
+	}
+	ranges, err := p.parseOr()
+	if err == nil && len(p.toks) > 0 {
+		err = p.errorf("unexpected %q", p.toks[0])
+	}
+	if err != nil {
+		return Constraint{}, err
+	}
+	return Constraint{text: strings.TrimSpace(s), ranges: ranges}, nil
+}
+
+// Check reports whether the version v satisfies the constraint.
+// Invalid versions never satisfy a constraint.
+func (c Constraint) Check(v string) bool {
+	x := parse(stripGo(v))
+	if x == (Version{}) {
+		return false
+	}
+	for _, r := range c.ranges {
+		if r.contains(x) {
+			return true
+		}
+	}
+	return false
+}
+
+// Satisfies reports whether the version v satisfies the constraint,
+// parsing the constraint as described by ParseConstraint.
+// It returns an error if the constraint or v is invalid.
+func Satisfies(v, constraint string) (bool, error) {
+	c, err := ParseConstraint(constraint)
+	if err != nil {
+		return false, err
+	}
+	if !IsValid(v) {
+		return false, fmt.Errorf("invalid version %q", v)
+	}
+	return c.Check(v), nil
+}
+
+// String returns the constraint as it was parsed.
+func (c Constraint) String() string {
+	return c.text
+}
+
+// MarshalText implements [encoding.TextMarshaler],
+// returning the constraint as it was parsed.
+func (c Constraint) MarshalText() ([]byte, error) {
+	return []byte(c.text), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler],
+// parsing the text using ParseConstraint.
+// Empty text unmarshals to the zero Constraint, so that the zero Constraint
+// round-trips through MarshalText.
+func (c *Constraint) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = Constraint{}
+		return nil
+	}
+	x, err := ParseConstraint(string(text))
+	if err != nil {
+		return err
+	}
+	*c = x
+	return nil
+}
+
+// MarshalTOML implements the Marshaler interface of
+// github.com/BurntSushi/toml, encoding the constraint as a TOML string
+// holding the constraint as it was parsed, as in go = ">=go1.21, <go1.24".
+func (c Constraint) MarshalTOML() ([]byte, error) {
+	return tomlString(c.text)
+}
+
+// UnmarshalTOML implements the Unmarshaler interface of
+// github.com/BurntSushi/toml, decoding a TOML string as with
+// [Constraint.UnmarshalText] and rejecting other TOML values.
+func (c *Constraint) UnmarshalTOML(data any) error {
+	s, ok := data.(string)
+	if !ok {
+		return fmt.Errorf("constraint must be a TOML string, as in \">=go1.21\", not %T", data)
+	}
+	return c.UnmarshalText([]byte(s))
+}
+
+// IsEmpty reports whether the constraint matches no versions,
+// as when it requires both ">=go1.22" and "<go1.21".
+func (c Constraint) IsEmpty() bool {
+	return len(c.ranges) == 0
+}
+
+// Intersect returns a constraint matching the versions
+// that satisfy both c and d.
+// Its String method returns "(c) && (d)".
+func (c Constraint) Intersect(d Constraint) Constraint {
+	if c.text == "" || d.text == "" {
+		return Constraint{}
+	}
+	return Constraint{
+		text:   "(" + c.text + ") && (" + d.text + ")",
+		ranges: intersectIntervals(c.ranges, d.ranges),
+	}
+}
+
+// Union returns a constraint matching the versions
+// that satisfy either c or d.
+// Its String method returns "(c) || (d)".
+func (c Constraint) Union(d Constraint) Constraint {
+	switch {
+	case c.text == "":
+		return d
+	case d.text == "":
+		return c
+	}
+	return Constraint{
+		text:   "(" + c.text + ") || (" + d.text + ")",
+		ranges: unionIntervals(c.ranges, d.ranges),
+	}
+}
+
+// tokenizeConstraint splits a constraint into the tokens
+// "(", ")", "&&", "||", and ",", and the terms between them.
+func tokenizeConstraint(s string) []string {
+	var toks []string
+	term := 0 // start of current term
+	flush := func(end int) {
+		if t := strings.TrimSpace(s[term:end]); t != "" {
+			toks = append(toks, t)
+		}
+	}
+	for i := 0; i < len(s); {
+		n := 0
+		switch {
+		case s[i] == '(' || s[i] == ')' || s[i] == ',':
+			n = 1
+		case strings.HasPrefix(s[i:], "&&") || strings.HasPrefix(s[i:], "||"):
+			n = 2
+		default:
+			i++
+			continue
+		}
+		flush(i)
+		toks = append(toks, s[i:i+n])
+		i += n
+		term = i
+	}
+	flush(len(s))
+	return toks
+}
+
+// A constraintParser is a recursive descent parser for constraints.
+// Each method returns the set of versions matching the expression it parses,
+// as a sorted list of disjoint, non-empty intervals.
+type constraintParser struct {
+	text string   // entire constraint
+	toks []string // remaining tokens
+}
+
+func (p *constraintParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid constraint %q: %s", p.text, fmt.Sprintf(format, args...))
+}
+
+// parseOr parses and-expressions separated by ||.
+func (p *constraintParser) parseOr() ([]interval, error) {
+	x, err := p.parseAnd()
+	for err == nil && len(p.toks) > 0 && p.toks[0] == "||" {
+		p.toks = p.toks[1:]
+		var y []interval
+		if y, err = p.parseAnd(); err == nil {
+			x = unionIntervals(x, y)
+		}
+	}
+	return x, err
+}
+
+// parseAnd parses operands separated by && or commas.
+func (p *constraintParser) parseAnd() ([]interval, error) {
+	x, err := p.parseOperand()
+	for err == nil && len(p.toks) > 0 && (p.toks[0] == "&&" || p.toks[0] == ",") {
+		p.toks = p.toks[1:]
+		var y []interval
+		if y, err = p.parseOperand(); err == nil {
+			x = intersectIntervals(x, y)
+		}
+	}
+	return x, err
+}
+
+// parseOperand parses a parenthesized expression or a single term.
+func (p *constraintParser) parseOperand() ([]interval, error) {
+	if len(p.toks) == 0 {
+		return nil, p.errorf("unexpected end of constraint")
+	}
+	tok := p.toks[0]
+	p.toks = p.toks[1:]
+	switch tok {
+	case "(":
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if len(p.toks) == 0 || p.toks[0] != ")" {
+			return nil, p.errorf("missing )")
+		}
+		p.toks = p.toks[1:]
+		return x, nil
+	case ")", "&&", "||", ",":
+		return nil, p.errorf("unexpected %q", tok)
+	}
+	r, err := p.parseTerm(tok)
+	if err != nil {
+		return nil, err
+	}
+	if r.empty() {
+		return nil, nil
+	}
+	return []interval{r}, nil
+}
+
+// parseTerm parses a single comparison or wildcard,
+// such as ">=go1.21" or "go1.21.x", and returns the range of versions it matches.
+func (p *constraintParser) parseTerm(term string) (interval, error) {
+	op := term[:len(term)-len(strings.TrimLeft(term, "<>=^~"))]
+	x := strings.TrimSpace(term[len(op):])
+	if r, ok := parseWildcard(x); ok {
+		if op != "" && op != "=" && op != "==" {
+			return interval{}, p.errorf("operator %q used with wildcard %q", op, x)
+		}
+		return r, nil
+	}
+	v, err := Parse(x)
+	if err != nil {
+		return interval{}, p.errorf("%v", err)
+	}
+	v.Suffix = ""
+	switch op {
+	case ">":
+		return interval{lo: bound{v, false}}, nil
+	case ">=":
+		return interval{lo: bound{v, true}}, nil
+	case "<":
+		return interval{hi: bound{v, false}}, nil
+	case "<=":
+		return interval{hi: bound{v, true}}, nil
+	case "", "=", "==":
+		return interval{bound{v, true}, bound{v, true}}, nil
+	case "^":
+		return interval{bound{v, true}, bound{Version{Major: IncInt(v.Major)}, false}}, nil
+	case "~":
+		hi := Version{Major: v.Major, Minor: IncInt(v.Minor)}
+		if !strings.Contains(x, ".") {
+			hi = Version{Major: IncInt(v.Major)}
+		}
+		return interval{bound{v, true}, bound{hi, false}}, nil
+	}
+	return interval{}, p.errorf("unknown operator %q", op)
+}
+
+// A bound is one end of a range of versions.
+// The bound version need not be a valid version: a Version with only a major
+// version or only major and minor versions set, such as {Major: "1", Minor: "20"},
+// compares less than every version in that series, including prereleases.
+type bound struct {
+	v   Version // version, or the zero Version for no bound
+	inc bool    // whether v itself is in the range
+}
+
+// An interval is the range of versions between two bounds.
+type interval struct {
+	lo, hi bound
+}
+
+// parseWildcard parses a wildcard pattern such as "go1.21.x" and returns
+// the range of versions it matches.
+func parseWildcard(x string) (interval, bool) {
+	if x == "*" {
+		return interval{}, true
+	}
+	prefix, ok := strings.CutSuffix(x, ".x")
+	if !ok {
+		prefix, ok = strings.CutSuffix(x, ".*")
+	}
+	if !ok || strings.Contains(prefix, "-") {
+		return interval{}, false
+	}
+	v := parse(stripGo(prefix))
+	if v == (Version{}) || v.Kind != "" {
+		return interval{}, false
+	}
+	switch strings.Count(prefix, ".") {
+	case 0:
+		// go1.x: go1 up to but not including the first Go 2 version.
+		return interval{
+			bound{Version{Major: v.Major, Minor: "0", Patch: "0"}, true},
+			bound{Version{Major: IncInt(v.Major)}, false},
+		}, true
+	case 1:
+		// go1.21.x: go1.21.0 up to but not including the first go1.22 version.
+		return interval{
+			bound{Version{Major: v.Major, Minor: v.Minor, Patch: "0"}, true},
+			bound{Version{Major: v.Major, Minor: IncInt(v.Minor)}, false},
+		}, true
+	}
+	return interval{}, false
+}
+
+// maxLo returns the tighter of two lower bounds.
+func maxLo(x, y bound) bound {
+	if x.v == (Version{}) {
+		return y
+	}
+	if y.v == (Version{}) {
+		return x
+	}
+	if c := x.v.Compare(y.v); c > 0 || c == 0 && !x.inc {
+		return x
+	}
+	return y
+}
+
+// minHi returns the tighter of two upper bounds.
+func minHi(x, y bound) bound {
+	if x.v == (Version{}) {
+		return y
+	}
+	if y.v == (Version{}) {
+		return x
+	}
+	if c := x.v.Compare(y.v); c < 0 || c == 0 && !x.inc {
+		return x
+	}
+	return y
+}
+
+// intersect returns the range of versions in both r and s.
+func (r interval) intersect(s interval) interval {
+	return interval{maxLo(r.lo, s.lo), minHi(r.hi, s.hi)}
+}
+
+// empty reports whether no version lies in r.
+func (r interval) empty() bool {
+	if r.lo.v == (Version{}) || r.hi.v == (Version{}) {
+		return false
+	}
+	c := r.lo.v.Compare(r.hi.v)
+	return c > 0 || c == 0 && !(r.lo.inc && r.hi.inc)
+}
+
+// contains reports whether the version v lies in r.
+func (r interval) contains(v Version) bool {
+	if r.lo.v != (Version{}) {
+		if c := v.Compare(r.lo.v); c < 0 || c == 0 && !r.lo.inc {
+			return false
+		}
+	}
+	if r.hi.v != (Version{}) {
+		if c := v.Compare(r.hi.v); c > 0 || c == 0 && !r.hi.inc {
+			return false
+		}
+	}
+	return true
+}
+
+// maxHi returns the looser of two upper bounds.
+func maxHi(x, y bound) bound {
+	if x.v == (Version{}) || y.v == (Version{}) {
+		return bound{}
+	}
+	if minHi(x, y) == x {
+		return y
+	}
+	return x
+}
+
+// cmpLo compares two lower bounds, ordering looser bounds first.
+func cmpLo(x, y bound) int {
+	switch {
+	case x == y:
+		return 0
+	case x.v == (Version{}):
+		return -1
+	case y.v == (Version{}):
+		return +1
+	}
+	if c := x.v.Compare(y.v); c != 0 {
+		return c
+	}
+	if x.inc {
+		return -1
+	}
+	return +1
+}
+
+// normalizeIntervals sorts the non-empty intervals in rs by lower bound
+// and merges any that overlap or touch, returning a list of disjoint intervals.
+// It modifies rs.
+func normalizeIntervals(rs []interval) []interval {
+	rs = slices.DeleteFunc(rs, interval.empty)
+	slices.SortFunc(rs, func(x, y interval) int { return cmpLo(x.lo, y.lo) })
+	var out []interval
+	for _, r := range rs {
+		if n := len(out); n > 0 && touches(out[n-1].hi, r.lo) {
+			out[n-1].hi = maxHi(out[n-1].hi, r.hi)
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// touches reports whether a range ending at hi and a later range
+// starting at lo overlap or leave no version between them.
+func touches(hi, lo bound) bool {
+	if hi.v == (Version{}) || lo.v == (Version{}) {
+		return true
+	}
+	c := lo.v.Compare(hi.v)
+	return c < 0 || c == 0 && (lo.inc || hi.inc)
+}
+
+// unionIntervals returns the sorted, disjoint intervals covering
+// the versions in either x or y.
+func unionIntervals(x, y []interval) []interval {
+	return normalizeIntervals(slices.Concat(x, y))
+}
+
+// intersectIntervals returns the sorted, disjoint intervals covering
+// the versions in both x and y.
+func intersectIntervals(x, y []interval) []interval {
+	var out []interval
+	for _, r := range x {
+		for _, s := range y {
+			out = append(out, r.intersect(s))
+		}
+	}
+	return normalizeIntervals(out)
+}
+
+// RequiredLangs returns a short sorted list of language versions such
+// that every constraint is satisfied by some release of one of them.
+// Each constraint is parsed by ParseConstraint, as in ">=go1.21, <go1.22" or "go1.22.3".
+// For example, RequiredLangs([]string{">=go1.20, <go1.21", ">=go1.22"})
+// returns ["go1.20", "go1.22"], while overlapping constraints such as
+// ">=go1.20" and "<go1.22" share the single language version "go1.21".
+// The list is as short as possible unless some constraints
+// offer alternatives using ||.
+// RequiredLangs returns an error if any constraint is invalid or
+// cannot be satisfied by any version.
+func RequiredLangs(constraints []string) ([]string, error) {
+	// Reduce each constraint to the ranges of language versions
+	// having at least one release that satisfies it.
+	type langRange struct {
+		lo, hi string // "" for no bound
+	}
+	type langSet struct {
+		ranges     []langRange // in increasing order
+		constraint string
+	}
+	var sets []langSet
+	for _, s := range constraints {
+		c, err := ParseConstraint(s)
+		if err != nil {
+			return nil, err
+		}
+		set := langSet{constraint: s}
+		for _, r := range c.ranges {
+			var lr langRange
+			if r.lo.v != (Version{}) {
+				lr.lo = r.lo.v.Lang().Canonical()
+			}
+			switch hi := r.hi; {
+			case hi.v == (Version{}) || hi.v.Minor == "":
+				// No bound, or a bound before the first version of a major version,
+				// which is higher than all language versions of the previous one.
+			case !hi.inc && hi.v.IsLangVersion():
+				// The language version go1.N is the first version in the go1.N series,
+				// so <go1.N only allows earlier series.
+				if hi.v.Minor == "0" {
+					continue
+				}
+				lr.hi = Version{Major: hi.v.Major, Minor: DecInt(hi.v.Minor)}.Lang().Canonical()
+			default:
+				lr.hi = hi.v.Lang().Canonical()
+			}
+			if lr.lo != "" && lr.hi != "" && Compare(lr.lo, lr.hi) > 0 {
+				continue
+			}
+			set.ranges = append(set.ranges, lr)
+		}
+		if len(set.ranges) == 0 {
+			return nil, fmt.Errorf("constraint %q cannot be satisfied", s)
+		}
+		sets = append(sets, set)
+	}
+
+	// Choose versions greedily: taking constraints in order of upper bound,
+	// if none of the chosen versions satisfies a constraint, choose its highest version,
+	// which covers as many of the remaining constraints as possible.
+	last := func(set langSet) langRange { return set.ranges[len(set.ranges)-1] }
+	slices.SortFunc(sets, func(x, y langSet) int {
+		xhi, yhi := last(x).hi, last(y).hi
+		if xhi == "" || yhi == "" {
+			return strings.Compare(yhi, xhi)
+		}
+		return Compare(xhi, yhi)
+	})
+	var langs []string
+	covers := func(set langSet, lang string) bool {
+		for _, r := range set.ranges {
+			if (r.lo == "" || Compare(r.lo, lang) <= 0) && (r.hi == "" || Compare(lang, r.hi) <= 0) {
+				return true
+			}
+		}
+		return false
+	}
+	var unbounded string     // highest lower bound of uncovered ranges with no upper bound
+	var unconstrained string // uncovered constraint with no bounds at all
+	for _, set := range sets {
+		if slices.ContainsFunc(langs, func(lang string) bool { return covers(set, lang) }) {
+			continue
+		}
+		switch r := last(set); {
+		case r.hi != "":
+			langs = append(langs, r.hi)
+		case r.lo == "":
+			unconstrained = set.constraint
+		case unbounded == "" || Compare(r.lo, unbounded) > 0:
+			unbounded = r.lo
+		}
+	}
+	if unbounded != "" {
+		langs = append(langs, unbounded)
+	}
+	if len(langs) == 0 && unconstrained != "" {
+		return nil, fmt.Errorf("constraint %q does not determine a language version", unconstrained)
+	}
+	slices.SortFunc(langs, Compare)
+	return slices.Compact(langs), nil
+}
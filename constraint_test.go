@@ -0,0 +1,338 @@
+package gover
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+var checkTests = []struct {
+	constraint string
+	v          string
+	out        bool
+}{
+	{">=go1.21, <go1.24", "go1.21", true},
+	{">=go1.21, <go1.24", "go1.21rc1", true},
+	{">=go1.21, <go1.24", "go1.21.0", true},
+	{">=go1.21, <go1.24", "go1.23.9", true},
+	{">=go1.21, <go1.24", "go1.24", false},
+	{">=go1.21, <go1.24", "go1.24rc1", false},
+	{">=go1.21, <go1.24", "go1.20.14", false},
+	{">go1.21", "go1.21", false},
+	{">go1.21", "go1.21rc1", true},
+	{"<=go1.21.3", "go1.21.3", true},
+	{"<=go1.21.3", "go1.21.3-bigcorp", true},
+	{"<=go1.21.3", "go1.21.4", false},
+	{"go1.22.3", "go1.22.3", true},
+	{"=go1.22.3", "go1.22.4", false},
+	{"==go1.20", "go1.20.0", true},
+	{" >= go1.20 ", "go1.20", true},
+	{">=go1.20", "1.21", false},
+	{">=go1.20", "bad", false},
+	{">=go1.22, <go1.21", "go1.21.5", false},
+
+	// Wildcards.
+	{"go1.21.x", "go1.21.0", true},
+	{"go1.21.x", "go1.21.13", true},
+	{"go1.21.*", "go1.21.13", true},
+	{"go1.21.x", "go1.21", false},
+	{"go1.21.x", "go1.21rc2", false},
+	{"go1.21.x", "go1.22", false},
+	{"go1.21.x", "go1.22rc1", false},
+	{"go1.21.x", "go1.20.14", false},
+	{"go1.19.x", "go1.19", true},
+	{"go1.19.x", "go1.19.13", true},
+	{"go1.19.x", "go1.19rc2", false},
+	{"go1.19.x", "go1.20rc1", false},
+	{"go1.19.x", "go1.20", false},
+	{"=go1.19.x", "go1.19.1", true},
+	{"go1.x", "go1", true},
+	{"go1.x", "go1.23.0", true},
+	{"go1.x", "go1.99999999999999999999", true},
+	{"go1.x", "go2", false},
+	{"go1.x", "go2rc1", false},
+	{"go2.x", "go2.0rc1", false},
+	{"*", "go1.2", true},
+	{"*", "bad", false},
+	{"go1.x, >=go1.21", "go1.22.1", true},
+	{"go1.x, >=go1.21", "go1.20", false},
+	{"go1.21.x, <go1.21.5", "go1.21.4", true},
+	{"go1.21.x, <go1.21.5", "go1.21.5", false},
+
+	// Caret and tilde.
+	{"^go1.21.3", "go1.21.3", true},
+	{"^go1.21.3", "go1.21.2", false},
+	{"^go1.21.3", "go1.30.0", true},
+	{"^go1.21.3", "go2", false},
+	{"^go1.21.3", "go2.0rc1", false},
+	{"~go1.21.3", "go1.21.3", true},
+	{"~go1.21.3", "go1.21.20", true},
+	{"~go1.21.3", "go1.21.2", false},
+	{"~go1.21.3", "go1.22", false},
+	{"~go1.21.3", "go1.22rc1", false},
+	{"~go1.21", "go1.21", true},
+	{"~go1.21", "go1.21rc1", true},
+	{"~go1.21", "go1.21.7", true},
+	{"~go1.21rc2", "go1.21rc1", false},
+	{"~go1.21rc2", "go1.21rc3", true},
+	{"~go1.21rc2", "go1.21.0", true},
+	{"~go1.20", "go1.20rc1", false},
+	{"~go1.20", "go1.20", true},
+	{"~go1.20", "go1.20.5", true},
+	{"~go1.20", "go1.21rc1", false},
+	{"~go1.19.5", "go1.20rc1", false},
+	{"~go1", "go1.30", true},
+	{"~go1", "go2", false},
+	{"^go1.21, <go1.23", "go1.22.9", true},
+	{"^go1.21, <go1.23", "go1.23.0", false},
+	{"(>=go1.21 && <go1.22) || >=go1.23.2", "go1.21.5", true},
+	{"(>=go1.21 && <go1.22) || >=go1.23.2", "go1.22.0", false},
+	{"(>=go1.21 && <go1.22) || >=go1.23.2", "go1.23.1", false},
+	{"(>=go1.21 && <go1.22) || >=go1.23.2", "go1.24.0", true},
+	{"go1.20.x || go1.22.x", "go1.21.3", false},
+	{"go1.20.x || go1.22.x", "go1.22.3", true},
+	{">=go1.21 && <go1.22 || go1.23.0", "go1.23.0", true},
+	{">=go1.21 && (<go1.22 || go1.23.0)", "go1.23.0", true},
+	{">=go1.22 && (<go1.22 || go1.21.0)", "go1.21.0", false},
+	{"<go1.21.3 || >go1.21.3", "go1.21.3", false},
+	{"<go1.21.3 || >go1.21.3", "go1.21.4", true},
+	{"<=go1.21.3 || >=go1.21.3", "go1.21.3", true},
+	{"((go1.21.x))", "go1.21.3", true},
+	{"go1.21.x, go1.22.x", "go1.21.3", false},
+}
+
+func TestCheck(t *testing.T) {
+	for _, tt := range checkTests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q): %v", tt.constraint, err)
+			continue
+		}
+		if out := c.Check(tt.v); out != tt.out {
+			t.Errorf("ParseConstraint(%q).Check(%q) = %v, want %v", tt.constraint, tt.v, out, tt.out)
+		}
+	}
+	if (Constraint{}).Check("go1.21") {
+		t.Errorf("zero Constraint matches go1.21")
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	for _, tt := range checkTests {
+		if !IsValid(tt.v) {
+			continue
+		}
+		out, err := Satisfies(tt.v, tt.constraint)
+		if out != tt.out || err != nil {
+			t.Errorf("Satisfies(%q, %q) = %v, %v, want %v, nil", tt.v, tt.constraint, out, err, tt.out)
+		}
+	}
+	for _, tt := range []struct{ v, constraint string }{
+		{"go1.21.0", ">=1.21"},
+		{"1.21.0", ">=go1.21"},
+		{"go1.021", "*"},
+	} {
+		if out, err := Satisfies(tt.v, tt.constraint); err == nil {
+			t.Errorf("Satisfies(%q, %q) = %v, nil, want error", tt.v, tt.constraint, out)
+		}
+	}
+}
+
+var parseConstraintErrorTests = []string{
+	"",
+	" , ",
+	">=1.21",
+	">=go1.21,",
+	">>go1.21",
+	"=<go1.21",
+	"go1.21 go1.22",
+	">=go1.21.x",
+	"go1.21.0.x",
+	"go1.21rc1.x",
+	"go1.21.x-bigcorp",
+	"go1.x.x",
+	"1.21.x",
+	"^^go1.21",
+	"~go1.21.x",
+	"^1.21",
+	"~>go1.21",
+	"()",
+	"(go1.21",
+	"go1.21)",
+	"go1.21 ||",
+	"|| go1.21",
+	">=go1.21 && && <go1.22",
+	"go1.21 (go1.22)",
+	"go1.21 | go1.22",
+	"go1.21 & go1.22",
+}
+
+func TestParseConstraintError(t *testing.T) {
+	for _, s := range parseConstraintErrorTests {
+		if c, err := ParseConstraint(s); err == nil {
+			t.Errorf("ParseConstraint(%q) = %v, want error", s, c)
+		}
+	}
+}
+
+var combineTests = []struct {
+	c, d      string
+	intersect bool // Intersect instead of Union
+	empty     bool
+	in, out   []string
+}{
+	{">=go1.21", "<go1.24", true, false, []string{"go1.21.0", "go1.23.9"}, []string{"go1.20.1", "go1.24.0"}},
+	{">=go1.22", "<go1.21", true, true, nil, []string{"go1.21.0", "go1.22.0"}},
+	{"go1.21.x || go1.23.x", "go1.22.x || go1.23.x", true, false, []string{"go1.23.1"}, []string{"go1.21.0", "go1.22.0"}},
+	{"go1.21.x", "go1.23.x", true, true, nil, []string{"go1.21.0", "go1.23.0"}},
+	{"go1.21.x", "go1.23.x", false, false, []string{"go1.21.0", "go1.23.0"}, []string{"go1.22.0"}},
+	{"<go1.21", ">=go1.21", false, false, []string{"go1.20.1", "go1.21rc1", "go2"}, nil},
+	{">=go1.22, <go1.21", ">=go1.24", false, false, []string{"go1.24.0"}, []string{"go1.21.0", "go1.22.0"}},
+}
+
+func TestCombine(t *testing.T) {
+	for _, tt := range combineTests {
+		c, err := ParseConstraint(tt.c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := ParseConstraint(tt.d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name, op, x := "Union", " || ", c.Union(d)
+		if tt.intersect {
+			name, op, x = "Intersect", " && ", c.Intersect(d)
+		}
+		if want := "(" + tt.c + ")" + op + "(" + tt.d + ")"; x.String() != want {
+			t.Errorf("%q.%s(%q).String() = %q, want %q", tt.c, name, tt.d, x, want)
+		}
+		if x.IsEmpty() != tt.empty {
+			t.Errorf("%q.%s(%q).IsEmpty() = %v, want %v", tt.c, name, tt.d, x.IsEmpty(), tt.empty)
+		}
+		for _, v := range tt.in {
+			if !x.Check(v) {
+				t.Errorf("%q.%s(%q).Check(%q) = false, want true", tt.c, name, tt.d, v)
+			}
+		}
+		for _, v := range tt.out {
+			if x.Check(v) {
+				t.Errorf("%q.%s(%q).Check(%q) = true, want false", tt.c, name, tt.d, v)
+			}
+		}
+		// The combined text must parse to the same constraint.
+		y, err := ParseConstraint(x.String())
+		if err != nil {
+			t.Errorf("ParseConstraint(%q): %v", x, err)
+		} else if !slices.Equal(y.ranges, x.ranges) {
+			t.Errorf("ParseConstraint(%q) = %v, want %v", x, y.ranges, x.ranges)
+		}
+	}
+
+	c, _ := ParseConstraint(">=go1.21")
+	if x := c.Union(Constraint{}); x.String() != c.String() || !x.Check("go1.21.0") {
+		t.Errorf("Union(Constraint{}) = %q, want %q", x, c)
+	}
+	if x := c.Intersect(Constraint{}); !x.IsEmpty() || x.String() != "" {
+		t.Errorf("Intersect(Constraint{}) = %q, want empty", x)
+	}
+	if !(Constraint{}).IsEmpty() {
+		t.Errorf("Constraint{}.IsEmpty() = false, want true")
+	}
+}
+
+func TestConstraintText(t *testing.T) {
+	var policy struct {
+		Go Constraint `json:"go"`
+	}
+	in := `{"go":"(>=go1.21 && <go1.22) || >=go1.23.2"}`
+	if err := json.Unmarshal([]byte(in), &policy); err != nil {
+		t.Fatal(err)
+	}
+	if !policy.Go.Check("go1.21.3") || policy.Go.Check("go1.22.0") {
+		t.Errorf("unmarshaled %q does not match as parsed", policy.Go)
+	}
+	out, err := json.Marshal(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again struct {
+		Go string `json:"go"`
+	}
+	if err := json.Unmarshal(out, &again); err != nil || again.Go != policy.Go.String() {
+		t.Errorf("json.Marshal = %s, want go field %q", out, policy.Go)
+	}
+
+	var c Constraint
+	if err := c.UnmarshalText([]byte(">=go1.21 &&")); err == nil {
+		t.Errorf("UnmarshalText(%q) succeeded, want error", ">=go1.21 &&")
+	}
+	if err := c.UnmarshalText(nil); err != nil || !c.IsEmpty() || c.String() != "" {
+		t.Errorf("UnmarshalText(nil) = %v, %q, want nil, zero Constraint", err, c)
+	}
+	if text, err := (Constraint{}).MarshalText(); err != nil || len(text) != 0 {
+		t.Errorf("Constraint{}.MarshalText() = %q, %v, want \"\", nil", text, err)
+	}
+}
+
+func TestConstraintTOML(t *testing.T) {
+	c, err := ParseConstraint(">=go1.21, <go1.24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := c.MarshalTOML()
+	if want := `">=go1.21, <go1.24"`; string(data) != want || err != nil {
+		t.Errorf("MarshalTOML(%q) = %s, %v, want %s", c, data, err, want)
+	}
+	var back Constraint
+	if err := back.UnmarshalTOML(">=go1.21, <go1.24"); err != nil || back.String() != c.String() || !back.Check("go1.23.1") {
+		t.Errorf("UnmarshalTOML = %q, %v, want %q", back, err, c)
+	}
+	for _, data := range []any{">=go1.21 &&", 1.21, int64(1)} {
+		if err := back.UnmarshalTOML(data); err == nil {
+			t.Errorf("UnmarshalTOML(%#v) succeeded, want error", data)
+		}
+	}
+}
+
+var requiredLangsTests = []struct {
+	constraints []string
+	out         []string
+	err         bool
+}{
+	{nil, nil, false},
+	{[]string{">=go1.20, <go1.21", ">=go1.22"}, []string{"go1.20", "go1.22"}, false},
+	{[]string{">=go1.20", "<go1.22"}, []string{"go1.21"}, false},
+	{[]string{">=go1.21.3, <go1.22", ">=go1.21rc1"}, []string{"go1.21"}, false},
+	{[]string{"go1.22.3", ">=go1.20, <=go1.22rc2", "go1.19.5"}, []string{"go1.19", "go1.22"}, false},
+	{[]string{">go1.21", ">=go1.23.1", "<go1.25"}, []string{"go1.24"}, false},
+	{[]string{"<go1.21", "<=go1.18.2"}, []string{"go1.18"}, false},
+	{[]string{"<go1.20"}, []string{"go1.20"}, false}, // go1.20rc1 < go1.20
+	{[]string{">=go1.22, <go1.22"}, nil, true},
+	{[]string{">go1.21.9, <go1.22"}, []string{"go1.21"}, false},
+	{[]string{">=go1.22, <go1.21"}, nil, true},
+	{[]string{""}, nil, true},
+	{[]string{">=1.21"}, nil, true},
+	{[]string{"~go1.21"}, []string{"go1.21"}, false},
+	{[]string{"^go1.21", "~go1.19.2"}, []string{"go1.19", "go1.21"}, false},
+	{[]string{"go1.19.x", "go1.21.x"}, []string{"go1.19", "go1.21"}, false},
+	{[]string{"go1.x", ">=go1.21, <go1.22"}, []string{"go1.21"}, false},
+	{[]string{"go1.x"}, []string{"go1"}, false},
+	{[]string{"*"}, nil, true},
+	{[]string{">=go1.20", "*"}, []string{"go1.20"}, false},
+	{[]string{"go1.19.x || go1.21.x", "go1.21.3"}, []string{"go1.21"}, false},
+	{[]string{"go1.19.x || go1.21.x", ">=go1.20"}, []string{"go1.21"}, false},
+	{[]string{"(>=go1.21 && <go1.22) || >=go1.23.2", "<go1.24"}, []string{"go1.23"}, false},
+	{[]string{"<go1 || go1.21.x"}, []string{"go1.21"}, false},
+	{[]string{"go1.21.x && go1.22.x"}, nil, true},
+}
+
+func TestRequiredLangs(t *testing.T) {
+	for _, tt := range requiredLangsTests {
+		out, err := RequiredLangs(tt.constraints)
+		if !reflect.DeepEqual(out, tt.out) || (err != nil) != tt.err {
+			t.Errorf("RequiredLangs(%q) = %q, %v, want %q, err=%v", tt.constraints, out, err, tt.out, tt.err)
+		}
+	}
+}
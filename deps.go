@@ -0,0 +1,155 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// A DependencyGo is the go directive of a module dependency.
+type DependencyGo struct {
+	Path    string // module path
+	Version string // module version
+	Go      string // go directive, such as "go1.21", or "" if none
+}
+
+// RequiredGo returns the go directive that the main module whose go.mod
+// file has the contents gomod must declare at least, because of its
+// dependencies: since Go 1.21, the go command requires a module's go
+// directive to be at least the go directive of every module it requires.
+// It returns the maximum go directive of the required modules, such as
+// "go1.22.1", or "" if none declares one, along with the go directive of
+// each required module, sorted by module path.
+//
+// RequiredGo reads the go.mod files of the required modules, which since
+// Go 1.17 include all modules needed to build the main module's packages,
+// from c's module proxy. Replacements by other module versions are
+// honored; replacements by local directories are skipped, since their
+// go.mod files are not available.
+// If gosum is not nil, it holds the contents of the main module's go.sum
+// file, and each go.mod file fetched must match its checksum there.
+// The main module's own code may of course require a newer version.
+func (c *Client) RequiredGo(ctx context.Context, gomod, gosum []byte) (string, []DependencyGo, error) {
+	f, err := modfile.Parse("go.mod", gomod, keepVersion)
+	if err != nil {
+		return "", nil, err
+	}
+	proxy, err := c.proxy()
+	if err != nil {
+		return "", nil, err
+	}
+	var sums map[string]string
+	if gosum != nil {
+		sums = parseGoSum(gosum)
+	}
+
+	var mods []module.Version
+	for _, r := range f.Require {
+		// As in the go command, a replacement of the exact version
+		// takes precedence over one of all versions.
+		m := r.Mod
+		for _, rep := range f.Replace {
+			if rep.Old.Path != r.Mod.Path {
+				continue
+			}
+			if rep.Old.Version == r.Mod.Version {
+				m = rep.New
+				break
+			}
+			if rep.Old.Version == "" {
+				m = rep.New
+			}
+		}
+		if m.Version != "" { // not a local directory
+			mods = append(mods, m)
+		}
+	}
+
+	// Fetch the go.mod files a few at a time.
+	deps := make([]DependencyGo, len(mods))
+	errs := make([]error, len(mods))
+	sem := make(chan struct{}, 8)
+	var wg sync.WaitGroup
+	for i, m := range mods {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			deps[i] = DependencyGo{Path: m.Path, Version: m.Version}
+			deps[i].Go, errs[i] = c.dependencyGo(ctx, proxy, m, sums)
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return "", nil, err
+	}
+
+	slices.SortFunc(deps, func(x, y DependencyGo) int { return strings.Compare(x.Path, y.Path) })
+	var max string
+	for _, d := range deps {
+		if d.Go != "" && (max == "" || Compare(d.Go, max) > 0) {
+			max = d.Go
+		}
+	}
+	return max, deps, nil
+}
+
+// dependencyGo returns the go directive of module m, whose go.mod file it
+// fetches from proxy and, if sums is not nil, checks against the hash in sums.
+func (c *Client) dependencyGo(ctx context.Context, proxy string, m module.Version, sums map[string]string) (string, error) {
+	path, err := module.EscapePath(m.Path)
+	if err != nil {
+		return "", err
+	}
+	vers, err := module.EscapeVersion(m.Version)
+	if err != nil {
+		return "", err
+	}
+	data, err := c.get(ctx, proxy+"/"+path+"/@v/"+vers+".mod")
+	if err != nil {
+		return "", err
+	}
+	if sums != nil {
+		want, ok := sums[m.Path+" "+m.Version+"/go.mod"]
+		if !ok {
+			return "", fmt.Errorf("missing go.sum entry for go.mod file of %s@%s", m.Path, m.Version)
+		}
+		have, err := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if have != want {
+			return "", fmt.Errorf("verifying %s@%s/go.mod: checksum mismatch: have %s, want %s", m.Path, m.Version, have, want)
+		}
+	}
+	goVersion, _, err := ModuleRequires(data)
+	if err != nil {
+		return "", fmt.Errorf("%s@%s: go.mod: %v", m.Path, m.Version, err)
+	}
+	return goVersion, nil
+}
+
+// parseGoSum returns the hashes in the go.sum file data,
+// keyed by module path and version, as in "example.com/m v1.2.3/go.mod".
+func parseGoSum(data []byte) map[string]string {
+	sums := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		f := strings.Fields(line)
+		if len(f) == 3 {
+			sums[f[0]+" "+f[1]] = f[2]
+		}
+	}
+	return sums
+}
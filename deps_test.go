@@ -0,0 +1,162 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// depsMods are the go.mod files served by the proxy of newDepsClient.
+var depsMods = map[string]string{
+	"example.com/a/@v/v1.0.0.mod":  "module example.com/a\n\ngo 1.21\n",
+	"example.com/b/@v/v1.2.0.mod":  "module example.com/b\n\ngo 1.22.1\n",
+	"example.com/b/@v/v1.3.0.mod":  "module example.com/b\n\ngo 1.23rc1\n",
+	"example.com/!c/@v/v0.1.0.mod": "module example.com/C\n",
+	"example.com/d/@v/v2.0.0.mod":  "module example.com/d\n\ngo 1.20\n",
+}
+
+// newDepsClient returns a client whose module proxy serves depsMods.
+func newDepsClient(t *testing.T) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := depsMods[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(data))
+	}))
+	t.Cleanup(srv.Close)
+	return &Client{CacheDir: "off", ProxyURL: srv.URL}
+}
+
+// goModHash returns the go.sum hash of the go.mod file data.
+func goModHash(t *testing.T, data string) string {
+	t.Helper()
+	h, err := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(data)), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestRequiredGo(t *testing.T) {
+	const gomod = `module example.com/main
+
+go 1.21
+
+require (
+	example.com/a v1.0.0
+	example.com/b v1.2.0
+	example.com/C v0.1.0 // indirect
+	example.com/local v0.0.0
+)
+
+replace example.com/local => ../local
+`
+	c := newDepsClient(t)
+	max, deps, err := c.RequiredGo(context.Background(), []byte(gomod), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if max != "go1.22.1" {
+		t.Errorf("RequiredGo = %q, want go1.22.1", max)
+	}
+	want := []DependencyGo{
+		{"example.com/C", "v0.1.0", ""},
+		{"example.com/a", "v1.0.0", "go1.21"},
+		{"example.com/b", "v1.2.0", "go1.22.1"},
+	}
+	if len(deps) != len(want) {
+		t.Fatalf("RequiredGo deps = %v, want %v", deps, want)
+	}
+	for i := range want {
+		if deps[i] != want[i] {
+			t.Errorf("deps[%d] = %v, want %v", i, deps[i], want[i])
+		}
+	}
+
+	// A replacement by another version is honored.
+	replaced := gomod + "replace example.com/b v1.2.0 => example.com/b v1.3.0\nreplace example.com/a => example.com/d v2.0.0\n"
+	max, _, err = c.RequiredGo(context.Background(), []byte(replaced), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if max != "go1.23rc1" {
+		t.Errorf("RequiredGo with replacements = %q, want go1.23rc1", max)
+	}
+
+	// A replacement of the exact version wins over one of all versions,
+	// whichever comes first.
+	for _, replaces := range []string{
+		"replace example.com/b v1.2.0 => example.com/b v1.3.0\nreplace example.com/b => example.com/d v2.0.0\n",
+		"replace example.com/b => example.com/d v2.0.0\nreplace example.com/b v1.2.0 => example.com/b v1.3.0\n",
+	} {
+		max, _, err = c.RequiredGo(context.Background(), []byte(gomod+replaces), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if max != "go1.23rc1" {
+			t.Errorf("RequiredGo with\n%s= %q, want go1.23rc1", replaces, max)
+		}
+	}
+
+	// No requirements.
+	max, deps, err = c.RequiredGo(context.Background(), []byte("module m\n\ngo 1.21\n"), nil)
+	if max != "" || len(deps) != 0 || err != nil {
+		t.Errorf("RequiredGo without requirements = %q, %v, %v, want \"\", [], nil", max, deps, err)
+	}
+}
+
+func TestRequiredGoSum(t *testing.T) {
+	const gomod = "module m\n\nrequire (\n\texample.com/a v1.0.0\n\texample.com/b v1.2.0\n)\n"
+	var gosum bytes.Buffer
+	gosum.WriteString("example.com/a v1.0.0 h1:ignored=\n")
+	gosum.WriteString("example.com/a v1.0.0/go.mod " + goModHash(t, depsMods["example.com/a/@v/v1.0.0.mod"]) + "\n")
+	gosum.WriteString("example.com/b v1.2.0/go.mod " + goModHash(t, depsMods["example.com/b/@v/v1.2.0.mod"]) + "\n")
+
+	c := newDepsClient(t)
+	max, _, err := c.RequiredGo(context.Background(), []byte(gomod), gosum.Bytes())
+	if err != nil || max != "go1.22.1" {
+		t.Errorf("RequiredGo with go.sum = %q, %v, want go1.22.1, nil", max, err)
+	}
+
+	tests := []struct {
+		name  string
+		gosum string
+		err   string
+	}{
+		{"missing", "example.com/a v1.0.0/go.mod " + goModHash(t, depsMods["example.com/a/@v/v1.0.0.mod"]) + "\n", "missing go.sum entry for go.mod file of example.com/b@v1.2.0"},
+		{"mismatch", gosum.String() + "example.com/b v1.2.0/go.mod h1:wrong=\n", "checksum mismatch"},
+	}
+	for _, tt := range tests {
+		_, _, err := c.RequiredGo(context.Background(), []byte(gomod), []byte(tt.gosum))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: RequiredGo error = %v, want %q", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestRequiredGoErrors(t *testing.T) {
+	c := newDepsClient(t)
+	for _, gomod := range []string{
+		"module m\n\nrequire example.com/missing v1.0.0\n",
+		"module m\n\nrequire (\n", // syntax error
+	} {
+		if _, _, err := c.RequiredGo(context.Background(), []byte(gomod), nil); err == nil {
+			t.Errorf("RequiredGo(%q) succeeded, want error", gomod)
+		}
+	}
+	offline := &Client{Offline: true}
+	if _, _, err := offline.RequiredGo(context.Background(), []byte("module m\n"), nil); err == nil {
+		t.Error("RequiredGo offline succeeded, want error")
+	}
+}
@@ -0,0 +1,57 @@
+package gover
+
+import (
+	"fmt"
+	"slices"
+)
+
+// EffectiveLang returns the effective Go language version for a build
+// made up of several modules, given a map from module path to that
+// module's go directive, such as "go1.21.3".
+// The effective language version is that of the maximum directive;
+// EffectiveLang also returns the path of the module that declared it.
+// If several modules declare equal maximum directives, the module path
+// that sorts first is reported.
+// If directives is empty, EffectiveLang returns empty strings.
+// If any directive is not a valid version, EffectiveLang returns an error.
+func EffectiveLang(directives map[string]string) (lang, module string, err error) {
+	paths := make([]string, 0, len(directives))
+	for path := range directives {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	var max string
+	for _, path := range paths {
+		v := directives[path]
+		if !IsValid(v) {
+			return "", "", fmt.Errorf("module %s: invalid go directive %q", path, v)
+		}
+		if module == "" || Compare(v, max) > 0 {
+			max, module = v, path
+		}
+	}
+	if module == "" {
+		return "", "", nil
+	}
+	return Lang(max), module, nil
+}
+
+// CanLowerDirective reports whether a module's go directive can safely be
+// changed from current to proposed. Lowering the directive may remove
+// language features that the module's code relies on, so CanLowerDirective
+// reports false for any proposed version that compares less than current,
+// along with an explanation. Equal or higher versions are allowed.
+// If either version is invalid, CanLowerDirective reports false.
+func CanLowerDirective(current, proposed string) (ok bool, reason string) {
+	if !IsValid(current) {
+		return false, fmt.Sprintf("invalid current go directive %q", current)
+	}
+	if !IsValid(proposed) {
+		return false, fmt.Sprintf("invalid proposed go directive %q", proposed)
+	}
+	if Compare(proposed, current) < 0 {
+		return false, fmt.Sprintf("%s is older than %s; lowering the go directive may disable language features the module uses", proposed, current)
+	}
+	return true, ""
+}
@@ -0,0 +1,69 @@
+package gover
+
+import "testing"
+
+var effectiveLangTests = []struct {
+	directives map[string]string
+	lang       string
+	module     string
+	err        bool
+}{
+	{nil, "", "", false},
+	{map[string]string{}, "", "", false},
+	{map[string]string{"example.com/a": "go1.20"}, "go1.20", "example.com/a", false},
+	{map[string]string{
+		"example.com/a": "go1.20",
+		"example.com/b": "go1.22",
+		"example.com/c": "go1.20.5",
+	}, "go1.22", "example.com/b", false},
+	{map[string]string{
+		"example.com/a": "go1.22.1",
+		"example.com/b": "go1.22rc1",
+		"example.com/c": "go1.21.9",
+	}, "go1.22", "example.com/a", false},
+	{map[string]string{
+		"example.com/b": "go1.21.0",
+		"example.com/a": "go1.21.0",
+	}, "go1.21", "example.com/a", false},
+	{map[string]string{
+		"example.com/a": "go1.22",
+		"example.com/b": "1.20",
+	}, "", "", true},
+}
+
+func TestEffectiveLang(t *testing.T) {
+	for _, tt := range effectiveLangTests {
+		lang, module, err := EffectiveLang(tt.directives)
+		if lang != tt.lang || module != tt.module || (err != nil) != tt.err {
+			t.Errorf("EffectiveLang(%v) = %q, %q, %v, want %q, %q, err=%v", tt.directives, lang, module, err, tt.lang, tt.module, tt.err)
+		}
+	}
+}
+
+var canLowerDirectiveTests = []struct {
+	current  string
+	proposed string
+	ok       bool
+}{
+	{"go1.22", "go1.21", false},
+	{"go1.22.1", "go1.22.0", false},
+	{"go1.21.0", "go1.21", false},
+	{"go1.21", "go1.21", true},
+	{"go1.20", "go1.20.0", true},
+	{"go1.21", "go1.22", true},
+	{"go1.21", "go1.21.0", true},
+	{"bad", "go1.21", false},
+	{"go1.21", "1.22", false},
+}
+
+func TestCanLowerDirective(t *testing.T) {
+	for _, tt := range canLowerDirectiveTests {
+		ok, reason := CanLowerDirective(tt.current, tt.proposed)
+		if ok != tt.ok {
+			t.Errorf("CanLowerDirective(%q, %q) = %v, %q, want %v", tt.current, tt.proposed, ok, reason, tt.ok)
+		}
+		if ok != (reason == "") {
+			t.Errorf("CanLowerDirective(%q, %q) = %v, %q: reason must be set exactly when not ok", tt.current, tt.proposed, ok, reason)
+		}
+	}
+}
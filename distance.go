@@ -0,0 +1,48 @@
+package gover
+
+import "fmt"
+
+// Distance returns the number of minor and patch steps from
+// the version x to the version y, as in "go1.20.5 is 3 minors and
+// 2 patches behind go1.23.2":
+//
+//	Distance("go1.20.5", "go1.23.2") = 3, 2, nil
+//	Distance("go1.21.1", "go1.21.4") = 0, 3, nil
+//	Distance("go1.21rc2", "go1.21.0") = 0, 0, nil
+//
+// When x and y belong to different language versions, patches is the patch
+// number of y, the steps taken within its series. Prereleases count as
+// the release they precede, and a language version as its first release.
+// If y is earlier than x, the results are negative:
+// Distance(x, y) returns the negation of Distance(y, x).
+// Distance returns an error if x or y is invalid or they have
+// different major versions.
+func Distance(x, y string) (minors, patches int, err error) {
+	vx, err := Parse(x)
+	if err != nil {
+		return 0, 0, err
+	}
+	vy, err := Parse(y)
+	if err != nil {
+		return 0, 0, err
+	}
+	if vx.Major != vy.Major {
+		return 0, 0, fmt.Errorf("versions %q and %q have different major versions", x, y)
+	}
+	if vx.Compare(vy) > 0 {
+		minors, patches, err = Distance(y, x)
+		return -minors, -patches, err
+	}
+	_, xMinor, xPatch, _, err := vx.Ints()
+	if err != nil {
+		return 0, 0, err
+	}
+	_, yMinor, yPatch, _, err := vy.Ints()
+	if err != nil {
+		return 0, 0, err
+	}
+	if xMinor == yMinor {
+		return 0, yPatch - xPatch, nil
+	}
+	return yMinor - xMinor, yPatch, nil
+}
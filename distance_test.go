@@ -0,0 +1,34 @@
+package gover
+
+import "testing"
+
+var distanceTests = []struct {
+	x, y    string
+	minors  int
+	patches int
+	err     bool
+}{
+	{"go1.20.5", "go1.23.2", 3, 2, false},
+	{"go1.21.1", "go1.21.4", 0, 3, false},
+	{"go1.21.4", "go1.21.1", 0, -3, false},
+	{"go1.23.2", "go1.20.5", -3, -2, false},
+	{"go1.21rc2", "go1.21.0", 0, 0, false},
+	{"go1.21", "go1.21.2", 0, 2, false},
+	{"go1.20", "go1.20.3", 0, 3, false},
+	{"go1.19.13", "go1.20", 1, 0, false},
+	{"go1.22.0", "go1.22.0", 0, 0, false},
+	{"go1.9.2rc2", "go1.9.3", 0, 1, false},
+	{"go1.21.0", "go2", 0, 0, true},
+	{"bad", "go1.21.0", 0, 0, true},
+	{"go1.21.0", "1.21.0", 0, 0, true},
+	{"go1.99999999999999999999", "go1.21.0", 0, 0, true},
+}
+
+func TestDistance(t *testing.T) {
+	for _, tt := range distanceTests {
+		minors, patches, err := Distance(tt.x, tt.y)
+		if minors != tt.minors || patches != tt.patches || (err != nil) != tt.err {
+			t.Errorf("Distance(%q, %q) = %d, %d, %v, want %d, %d, err=%v", tt.x, tt.y, minors, patches, err, tt.minors, tt.patches, tt.err)
+		}
+	}
+}
@@ -0,0 +1,365 @@
+package gover
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultDownloadURL is the Go download server.
+const defaultDownloadURL = "https://go.dev/dl/"
+
+// A Client queries the Go download server at https://go.dev/dl/,
+// or a mirror of it, for the list of published Go versions,
+// the Go vulnerability database for advisories affecting them,
+// and the Go module proxy for toolchains published as modules.
+//
+// A Client caches the list it fetches, in memory and in CacheDir,
+// and revalidates it with conditional requests, using the ETag and
+// Last-Modified headers of the response, so that repeated queries
+// transfer the list only when it changes.
+// A Client is safe for concurrent use. The zero Client is ready to use;
+// a Client must not be copied after first use.
+type Client struct {
+	// HTTPClient is the client used to make requests.
+	// If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// CacheDir is the directory in which the client caches the list
+	// of versions across processes. If empty, the client uses the
+	// "gover" subdirectory of os.UserCacheDir, if one is available.
+	// If CacheDir is "off", the list is cached only in memory.
+	CacheDir string
+
+	// TTL is how long a cached list is used without asking the server
+	// whether it has changed. If zero, every query contacts the server.
+	TTL time.Duration
+
+	// Offline makes the client answer queries without any network access,
+	// using the cached list regardless of its age or, if there is none,
+	// the release table embedded in the package, which lists
+	// versions but no files.
+	Offline bool
+
+	// BaseURL is the base URL of the download server, used both for the
+	// list of versions and for downloading files, such as
+	// "https://golang.google.cn/dl/" or the URL of an internal mirror.
+	// If empty, the client uses the value of the GOVER_MIRROR
+	// environment variable, or else "https://go.dev/dl/".
+	BaseURL string
+
+	// VulnDBURL is the base URL of the Go vulnerability database.
+	// If empty, the client uses the value of the GOVULNDB
+	// environment variable, or else "https://vuln.go.dev".
+	VulnDBURL string
+
+	// ProxyURL is the base URL of the module proxy through which
+	// toolchains are listed and downloaded as versions of the
+	// golang.org/toolchain module; see [Client.ProxyToolchains].
+	// If empty, the client uses the first proxy listed in the GOPROXY
+	// environment variable, or else "https://proxy.golang.org".
+	ProxyURL string
+
+	// SumDBURL is the base URL of the checksum database sum.golang.org,
+	// used to verify toolchains downloaded from the module proxy.
+	// If empty, the client accesses the database through the module proxy,
+	// if the proxy supports that, or else at "https://sum.golang.org".
+	SumDBURL string
+
+	mu    sync.Mutex
+	cache *dlCache // last list fetched, or nil
+
+	sumdbMu     sync.Mutex
+	sumdbConfig map[string][]byte // checksum database configuration, such as the latest tree
+	sumdbCache  map[string][]byte // checksum database tiles
+}
+
+// A dlCache is a list fetched from the download server,
+// with the validators needed to revalidate it.
+// It is stored in the cache directory in JSON form.
+type dlCache struct {
+	URL          string    `json:"url"`
+	Fetched      time.Time `json:"fetched"` // when the list was last fetched or revalidated
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	List         []Release `json:"list"`
+}
+
+// A Release is a Go version published on the download server.
+type Release struct {
+	Version string `json:"version"` // such as "go1.21.3"
+	Stable  bool   `json:"stable"`  // false for prereleases
+	Files   []File `json:"files"`
+}
+
+// A File is a downloadable file of a Release.
+type File struct {
+	Filename string `json:"filename"` // such as "go1.21.3.linux-amd64.tar.gz"
+	OS       string `json:"os"`       // GOOS, or "" for the source archive
+	Arch     string `json:"arch"`     // GOARCH, or "" for the source archive
+	Version  string `json:"version"`
+	SHA256   string `json:"sha256"` // hex-encoded SHA-256 checksum
+	Size     int64  `json:"size"`
+	Kind     string `json:"kind"` // "archive", "installer", or "source"
+}
+
+// List returns every version published on the download server,
+// including prereleases and releases no longer supported,
+// newest first as listed by the server.
+// The caller must not modify the returned slice.
+func (c *Client) List(ctx context.Context) ([]Release, error) {
+	url := c.base() + "?mode=json&include=all"
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cache == nil || c.cache.URL != url {
+		c.cache = c.readCache(url)
+	}
+	if c.Offline {
+		if c.cache != nil {
+			return c.cache.List, nil
+		}
+		return embeddedList(), nil
+	}
+	if c.cache != nil && c.TTL > 0 && time.Since(c.cache.Fetched) < c.TTL {
+		return c.cache.List, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.cache != nil {
+		if c.cache.ETag != "" {
+			req.Header.Set("If-None-Match", c.cache.ETag)
+		}
+		if c.cache.LastModified != "" {
+			req.Header.Set("If-Modified-Since", c.cache.LastModified)
+		}
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotModified && c.cache != nil:
+		c.cache.Fetched = time.Now()
+		c.writeCache()
+		return c.cache.List, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("fetching %s: %s", req.URL, resp.Status)
+	}
+	var list []Release
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("fetching %s: %v", req.URL, err)
+	}
+	if list == nil {
+		list = []Release{}
+	}
+	c.cache = &dlCache{
+		URL:          url,
+		Fetched:      time.Now(),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		List:         list,
+	}
+	c.writeCache()
+	return list, nil
+}
+
+// httpClient returns the HTTP client to use for requests.
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// base returns the base URL of the download server, ending in a slash.
+func (c *Client) base() string {
+	base := cmp.Or(c.BaseURL, os.Getenv("GOVER_MIRROR"), defaultDownloadURL)
+	if !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+	return base
+}
+
+// URL returns the URL from which the file with the given name,
+// such as "go1.21.3.linux-amd64.tar.gz", is downloaded
+// from the download server.
+func (c *Client) URL(filename string) string {
+	return c.base() + filename
+}
+
+// cacheFile returns the name of the file caching the list fetched from url,
+// or "" if there is no cache directory.
+func (c *Client) cacheFile(url string) string {
+	dir := c.CacheDir
+	switch dir {
+	case "off":
+		return ""
+	case "":
+		d, err := os.UserCacheDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(d, "gover")
+	}
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(dir, "dl-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// readCache returns the list cached on disk for url, or nil if none.
+func (c *Client) readCache(url string) *dlCache {
+	file := c.cacheFile(url)
+	if file == "" {
+		return nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	cache := new(dlCache)
+	if err := json.Unmarshal(data, cache); err != nil || cache.URL != url || cache.List == nil {
+		return nil
+	}
+	return cache
+}
+
+// writeCache writes c.cache to disk.
+// The cache is only an optimization, so errors are ignored.
+func (c *Client) writeCache() {
+	file := c.cacheFile(c.cache.URL)
+	if file == "" {
+		return
+	}
+	data, err := json.Marshal(c.cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o777); err != nil {
+		return
+	}
+	// Write to a temporary file and rename it into place,
+	// so that concurrent readers never see a partial file.
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".tmp*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), file)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// embeddedList returns the versions in the embedded release table
+// in the form of a download server list, newest first.
+func embeddedList() []Release {
+	table := releaseTable()
+	list := make([]Release, 0, len(table))
+	for i := len(table) - 1; i >= 0; i-- {
+		list = append(list, Release{Version: table[i].version, Stable: table[i].stable})
+	}
+	return list
+}
+
+// versions returns the valid versions listed by the download server.
+func (c *Client) versions(ctx context.Context) ([]string, error) {
+	list, err := c.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var vs []string
+	for _, r := range list {
+		if IsValid(r.Version) {
+			vs = append(vs, r.Version)
+		}
+	}
+	return vs, nil
+}
+
+// Latest returns the newest published Go version, including prereleases,
+// as with ResolveAlias("latest", ...).
+func (c *Client) Latest(ctx context.Context) (string, error) {
+	vs, err := c.versions(ctx)
+	if err != nil {
+		return "", err
+	}
+	return ResolveAlias("latest", vs)
+}
+
+// LatestStable returns the newest published Go release,
+// as with ResolveAlias("stable", ...).
+func (c *Client) LatestStable(ctx context.Context) (string, error) {
+	vs, err := c.versions(ctx)
+	if err != nil {
+		return "", err
+	}
+	return ResolveAlias("stable", vs)
+}
+
+// LatestPatchOf returns the newest published release
+// of the language version of lang, such as "go1.21.13" for "go1.21".
+func (c *Client) LatestPatchOf(ctx context.Context, lang string) (string, error) {
+	if !IsValid(lang) {
+		return "", fmt.Errorf("invalid version %q", lang)
+	}
+	vs, err := c.versions(ctx)
+	if err != nil {
+		return "", err
+	}
+	lang = Lang(lang)
+	var latest string
+	for _, v := range vs {
+		if IsRelease(v) && Lang(v) == lang && (latest == "" || Compare(v, latest) > 0) {
+			latest = v
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no release of %s", lang)
+	}
+	return latest, nil
+}
+
+// Patches returns every release of the language version of lang,
+// such as go1.21.0 through go1.21.13 for "go1.21", in increasing order.
+// It combines the release table embedded in the package with the
+// versions listed by the download server, so that the result includes
+// both releases no longer offered for download and releases newer
+// than the embedded table.
+func (c *Client) Patches(ctx context.Context, lang string) ([]string, error) {
+	if !IsValid(lang) {
+		return nil, fmt.Errorf("invalid version %q", lang)
+	}
+	vs, err := c.versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range releaseTable() {
+		vs = append(vs, r.version)
+	}
+	lang = Lang(lang)
+	var set VersionSet
+	for _, v := range vs {
+		if IsRelease(v) && Lang(v) == lang {
+			set.Add(v)
+		}
+	}
+	return set.Versions(), nil
+}
@@ -0,0 +1,298 @@
+package gover
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+const dlIndex = `[
+	{"version": "go1.24rc1", "stable": false, "files": []},
+	{"version": "go1.23.4", "stable": true, "files": []},
+	{"version": "go1.22.10", "stable": true, "files": []},
+	{"version": "go1.23.3", "stable": true, "files": []},
+	{"version": "go1.22.9", "stable": true, "files": []},
+	{"version": "go1.20", "stable": true, "files": []}
+]`
+
+// newTestClient returns a Client using a test server that serves index
+// as the download server's JSON index.
+func newTestClient(t *testing.T, index string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" || r.URL.Query().Get("mode") != "json" || r.URL.Query().Get("include") != "all" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(index))
+	}))
+	t.Cleanup(srv.Close)
+	return &Client{HTTPClient: srv.Client(), CacheDir: t.TempDir(), BaseURL: srv.URL}
+}
+
+func TestClientLatest(t *testing.T) {
+	c := newTestClient(t, dlIndex)
+	ctx := context.Background()
+	if v, err := c.Latest(ctx); v != "go1.24rc1" || err != nil {
+		t.Errorf("Latest() = %q, %v, want go1.24rc1, nil", v, err)
+	}
+	if v, err := c.LatestStable(ctx); v != "go1.23.4" || err != nil {
+		t.Errorf("LatestStable() = %q, %v, want go1.23.4, nil", v, err)
+	}
+	for _, tt := range []struct{ lang, out string }{
+		{"go1.22", "go1.22.10"},
+		{"go1.23.1", "go1.23.4"},
+		{"go1.20", "go1.20"},
+	} {
+		if v, err := c.LatestPatchOf(ctx, tt.lang); v != tt.out || err != nil {
+			t.Errorf("LatestPatchOf(%q) = %q, %v, want %q, nil", tt.lang, v, err, tt.out)
+		}
+	}
+	for _, lang := range []string{"go1.24", "go1.21", "1.22"} {
+		if v, err := c.LatestPatchOf(ctx, lang); err == nil {
+			t.Errorf("LatestPatchOf(%q) = %q, nil, want error", lang, v)
+		}
+	}
+}
+
+func TestClientError(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, `{"not": "a list"}`)
+	if v, err := c.Latest(ctx); err == nil {
+		t.Errorf("Latest() with bad JSON = %q, nil, want error", v)
+	}
+	c.BaseURL += "/missing/"
+	if v, err := c.LatestStable(ctx); err == nil {
+		t.Errorf("LatestStable() with 404 = %q, nil, want error", v)
+	}
+	c = newTestClient(t, dlIndex)
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if v, err := c.Latest(ctx); err == nil {
+		t.Errorf("Latest() with canceled context = %q, nil, want error", v)
+	}
+}
+
+func TestClientPatches(t *testing.T) {
+	c := newTestClient(t, `[
+		{"version": "go1.21.13", "stable": true},
+		{"version": "go1.21.14", "stable": true},
+		{"version": "go1.22rc1", "stable": false},
+		{"version": "go1.99.0", "stable": true},
+		{"version": "go1.99.1", "stable": true}
+	]`)
+	ctx := context.Background()
+
+	patches, err := c.Patches(ctx, "go1.21")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 15 || patches[0] != "go1.21.0" || patches[14] != "go1.21.14" {
+		t.Errorf("Patches(go1.21) = %q, want go1.21.0 through go1.21.14", patches)
+	}
+
+	patches, err = c.Patches(ctx, "go1.99rc1")
+	if err != nil || !slices.Equal(patches, []string{"go1.99.0", "go1.99.1"}) {
+		t.Errorf("Patches(go1.99rc1) = %q, %v, want [go1.99.0 go1.99.1], nil", patches, err)
+	}
+
+	patches, err = c.Patches(ctx, "go1.7")
+	if err != nil || !slices.Equal(patches, []string{"go1.7", "go1.7.1", "go1.7.3", "go1.7.4", "go1.7.5", "go1.7.6"}) {
+		t.Errorf("Patches(go1.7) = %q, %v", patches, err)
+	}
+
+	if patches, err := c.Patches(ctx, "go1.98"); err != nil || len(patches) != 0 {
+		t.Errorf("Patches(go1.98) = %q, %v, want [], nil", patches, err)
+	}
+	if patches, err := c.Patches(ctx, "1.21"); err == nil {
+		t.Errorf("Patches(1.21) = %q, nil, want error", patches)
+	}
+}
+
+func TestClientList(t *testing.T) {
+	const index = `[{"version": "go1.23.4", "stable": true, "files": [
+		{"filename": "go1.23.4.linux-amd64.tar.gz", "os": "linux", "arch": "amd64", "version": "go1.23.4",
+		"sha256": "6924efde5de86fe277676e929dc9917d466efa02fb934197bc2eba35d5680971", "size": 73645095, "kind": "archive"}
+	]}]`
+	var full, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("If-None-Match") == `"v1"`:
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			full++
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte(index))
+		}
+	}))
+	defer srv.Close()
+	c := &Client{HTTPClient: srv.Client(), CacheDir: t.TempDir(), BaseURL: srv.URL}
+	ctx := context.Background()
+
+	want := []Release{{
+		Version: "go1.23.4",
+		Stable:  true,
+		Files: []File{{
+			Filename: "go1.23.4.linux-amd64.tar.gz",
+			OS:       "linux",
+			Arch:     "amd64",
+			Version:  "go1.23.4",
+			SHA256:   "6924efde5de86fe277676e929dc9917d466efa02fb934197bc2eba35d5680971",
+			Size:     73645095,
+			Kind:     "archive",
+		}},
+	}}
+	for range 3 {
+		list, err := c.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(list, want) {
+			t.Fatalf("List() = %+v, want %+v", list, want)
+		}
+	}
+	if full != 1 || notModified != 2 {
+		t.Errorf("server saw %d full and %d conditional requests, want 1 and 2", full, notModified)
+	}
+}
+
+func TestClientListLastModified(t *testing.T) {
+	const lastModified = "Tue, 03 Dec 2024 18:00:00 GMT"
+	var full int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("Last-Modified", lastModified)
+		w.Write([]byte(dlIndex))
+	}))
+	defer srv.Close()
+	c := &Client{HTTPClient: srv.Client(), CacheDir: t.TempDir(), BaseURL: srv.URL}
+	for range 2 {
+		if v, err := c.LatestStable(context.Background()); v != "go1.23.4" || err != nil {
+			t.Fatalf("LatestStable() = %q, %v, want go1.23.4, nil", v, err)
+		}
+	}
+	if full != 1 {
+		t.Errorf("server saw %d full requests, want 1", full)
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(dlIndex))
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+	ctx := context.Background()
+	newClient := func() *Client {
+		return &Client{HTTPClient: srv.Client(), CacheDir: dir, BaseURL: srv.URL}
+	}
+
+	// A fresh list is served from memory within the TTL.
+	c := newClient()
+	c.TTL = time.Hour
+	for range 2 {
+		if v, err := c.LatestStable(ctx); v != "go1.23.4" || err != nil {
+			t.Fatalf("LatestStable() = %q, %v, want go1.23.4, nil", v, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("server saw %d requests within TTL, want 1", requests)
+	}
+
+	// A new client finds the list on disk: within the TTL it makes no request,
+	// and without a TTL it revalidates the cached list.
+	c = newClient()
+	c.TTL = time.Hour
+	if v, err := c.Latest(ctx); v != "go1.24rc1" || err != nil {
+		t.Fatalf("Latest() = %q, %v, want go1.24rc1, nil", v, err)
+	}
+	if requests != 1 {
+		t.Errorf("server saw %d requests with disk cache within TTL, want 1", requests)
+	}
+	c = newClient()
+	if v, err := c.Latest(ctx); v != "go1.24rc1" || err != nil {
+		t.Fatalf("Latest() = %q, %v, want go1.24rc1, nil", v, err)
+	}
+	if requests != 2 {
+		t.Errorf("server saw %d requests after revalidation, want 2", requests)
+	}
+
+	// An offline client uses the disk cache, however old, without the server.
+	c = newClient()
+	c.Offline = true
+	srv.Close()
+	if v, err := c.LatestStable(ctx); v != "go1.23.4" || err != nil {
+		t.Errorf("offline LatestStable() = %q, %v, want go1.23.4, nil", v, err)
+	}
+
+	// With no cache, an offline client uses the embedded table.
+	c = &Client{CacheDir: t.TempDir(), Offline: true, BaseURL: srv.URL}
+	want, err := ResolveAlias("stable", slices.Collect(Releases("", "")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.LatestStable(ctx); v != want || err != nil {
+		t.Errorf("offline LatestStable() without cache = %q, %v, want %q, nil", v, err, want)
+	}
+	if v, err := c.LatestPatchOf(ctx, "go1.21"); v != "go1.21.13" || err != nil {
+		t.Errorf("offline LatestPatchOf(go1.21) without cache = %q, %v, want go1.21.13, nil", v, err)
+	}
+
+	// With the cache off, there is no cache file.
+	c = &Client{CacheDir: "off"}
+	if file := c.cacheFile(srv.URL + "/?mode=json&include=all"); file != "" {
+		t.Errorf("cacheFile with CacheDir off = %q, want \"\"", file)
+	}
+	if files, err := os.ReadDir(dir); err != nil || len(files) != 1 {
+		t.Errorf("cache directory has %d files, %v, want 1", len(files), err)
+	}
+}
+
+func TestClientBaseURL(t *testing.T) {
+	t.Setenv("GOVER_MIRROR", "")
+	var c Client
+	if u := c.URL("go1.21.3.linux-amd64.tar.gz"); u != "https://go.dev/dl/go1.21.3.linux-amd64.tar.gz" {
+		t.Errorf("default URL = %q", u)
+	}
+	t.Setenv("GOVER_MIRROR", "https://golang.google.cn/dl")
+	if u := c.URL("go1.21.3.src.tar.gz"); u != "https://golang.google.cn/dl/go1.21.3.src.tar.gz" {
+		t.Errorf("URL with GOVER_MIRROR = %q", u)
+	}
+	c.BaseURL = "https://mirror.example.com/golang/"
+	if u := c.URL("go1.21.3.src.tar.gz"); u != "https://mirror.example.com/golang/go1.21.3.src.tar.gz" {
+		t.Errorf("URL with BaseURL = %q", u)
+	}
+
+	// The mirror also serves the list of versions.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mirror/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(dlIndex))
+	}))
+	defer srv.Close()
+	t.Setenv("GOVER_MIRROR", srv.URL+"/mirror")
+	c = Client{HTTPClient: srv.Client(), CacheDir: "off"}
+	if v, err := c.LatestStable(context.Background()); v != "go1.23.4" || err != nil {
+		t.Errorf("LatestStable() from GOVER_MIRROR = %q, %v, want go1.23.4, nil", v, err)
+	}
+}
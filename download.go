@@ -0,0 +1,163 @@
+package gover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// A ProgressFunc reports the progress of a download:
+// done bytes of total have been stored, or total is -1 if unknown.
+type ProgressFunc func(done, total int64)
+
+// Download downloads the named file, such as "go1.21.3.linux-amd64.tar.gz",
+// from c's download server into the file dst.
+//
+// The file is first written to dst+".partial" and renamed to dst when complete.
+// If a previous download left a partial file, Download resumes it by
+// requesting only the remaining bytes, if the server supports range requests.
+// If progress is not nil, Download calls it as data arrives.
+// Download stops when ctx is canceled, leaving the partial file for
+// a later call to resume. It does not verify the downloaded file;
+// see [Client.VerifyArchive].
+// Download fails if c is offline.
+func (c *Client) Download(ctx context.Context, filename, dst string, progress ProgressFunc) error {
+	if c.Offline {
+		return errors.New("cannot download " + filename + " offline")
+	}
+	return c.fetchFile(ctx, c.URL(filename), dst, progress, nil)
+}
+
+// fetchFile downloads url into the file dst, as described for Download.
+// If check is not nil, it is called with the name of the complete
+// partial file before it is renamed to dst; if check fails, the
+// partial file is removed, so that the next attempt starts over.
+func (c *Client) fetchFile(ctx context.Context, url, dst string, progress ProgressFunc, check func(file string) error) error {
+	partial := dst + ".partial"
+	err := c.download(ctx, url, partial, progress)
+	if err == errRangeNotSatisfiable {
+		// The partial file is stale or larger than the file on the server.
+		// Start over.
+		if err := os.Remove(partial); err != nil {
+			return err
+		}
+		err = c.download(ctx, url, partial, progress)
+	}
+	if err != nil {
+		return err
+	}
+	if check != nil {
+		if err := check(partial); err != nil {
+			os.Remove(partial)
+			return err
+		}
+	}
+	return os.Rename(partial, dst)
+}
+
+var errRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// download downloads url into the file partial,
+// resuming from the end of what partial already holds.
+func (c *Client) download(ctx context.Context, url, partial string, progress ProgressFunc) error {
+	f, err := os.OpenFile(partial, os.O_RDWR|os.O_CREATE, 0o666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Server sent the whole file.
+		if offset > 0 {
+			if err := f.Truncate(0); err != nil {
+				return err
+			}
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			offset = 0
+		}
+	case http.StatusPartialContent:
+		start, ok := contentRangeStart(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			return fmt.Errorf("downloading %s: unexpected Content-Range %q", url, resp.Header.Get("Content-Range"))
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		if offset > 0 {
+			return errRangeNotSatisfiable
+		}
+		fallthrough
+	default:
+		return fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	w := io.Writer(f)
+	if progress != nil {
+		progress(offset, total)
+		w = &progressWriter{w: f, done: offset, total: total, progress: progress}
+	}
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return fmt.Errorf("downloading %s: %v", url, err)
+	}
+	if total >= 0 && offset+n != total {
+		return fmt.Errorf("downloading %s: got %d bytes, want %d", url, offset+n, total)
+	}
+	return f.Close()
+}
+
+// contentRangeStart returns the first byte position in a
+// Content-Range header such as "bytes 100-199/200".
+func contentRangeStart(h string) (int64, bool) {
+	rest, ok := strings.CutPrefix(h, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	start, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(start, 10, 64)
+	return n, err == nil
+}
+
+// A progressWriter is a writer that reports the bytes written to a ProgressFunc.
+type progressWriter struct {
+	w           io.Writer
+	done, total int64
+	progress    ProgressFunc
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	p.progress(p.done, p.total)
+	return n, err
+}
@@ -0,0 +1,123 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newDownloadClient returns a client whose download server
+// serves data as every file, counting the requests it receives.
+func newDownloadClient(t *testing.T, data []byte, ranges *[]string) *Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ranges != nil {
+			*ranges = append(*ranges, r.Header.Get("Range"))
+		}
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(data))
+	}))
+	t.Cleanup(srv.Close)
+	return &Client{CacheDir: "off", BaseURL: srv.URL}
+}
+
+func TestDownload(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 1000)
+	c := newDownloadClient(t, data, nil)
+	dst := filepath.Join(t.TempDir(), "go1.21.3.linux-amd64.tar.gz")
+	var last, total int64
+	err := c.Download(context.Background(), "go1.21.3.linux-amd64.tar.gz", dst, func(d, t int64) { last, total = d, t })
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(dst)
+	if err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("downloaded %d bytes, %v, want %d bytes", len(got), err, len(data))
+	}
+	if last != int64(len(data)) || total != int64(len(data)) {
+		t.Errorf("last progress = %d/%d, want %d/%d", last, total, len(data), len(data))
+	}
+	if _, err := os.Stat(dst + ".partial"); !os.IsNotExist(err) {
+		t.Errorf("partial file left behind: %v", err)
+	}
+}
+
+func TestDownloadResume(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 1000)
+	for _, prefix := range []int{100, len(data)} {
+		var ranges []string
+		c := newDownloadClient(t, data, &ranges)
+		dst := filepath.Join(t.TempDir(), "go.tar.gz")
+		if err := os.WriteFile(dst+".partial", data[:prefix], 0o666); err != nil {
+			t.Fatal(err)
+		}
+		var first int64 = -1
+		err := c.Download(context.Background(), "go.tar.gz", dst, func(d, t int64) {
+			if first < 0 {
+				first = d
+			}
+		})
+		if err != nil {
+			t.Fatalf("prefix %d: %v", prefix, err)
+		}
+		got, _ := os.ReadFile(dst)
+		if !bytes.Equal(got, data) {
+			t.Errorf("prefix %d: downloaded %d bytes, want %d", prefix, len(got), len(data))
+		}
+		if want := "bytes=" + strconv.Itoa(prefix) + "-"; len(ranges) == 0 || ranges[0] != want {
+			t.Errorf("prefix %d: Range headers %q, want %q first", prefix, ranges, want)
+		}
+		if prefix < len(data) && first != int64(prefix) {
+			t.Errorf("prefix %d: first progress %d, want %d", prefix, first, prefix)
+		}
+	}
+}
+
+func TestDownloadNoRange(t *testing.T) {
+	// A server that ignores Range headers sends the whole file again.
+	data := []byte("the whole file")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	defer srv.Close()
+	c := &Client{CacheDir: "off", BaseURL: srv.URL}
+	dst := filepath.Join(t.TempDir(), "go.tar.gz")
+	if err := os.WriteFile(dst+".partial", []byte("stale partial contents that are long"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Download(context.Background(), "go.tar.gz", dst, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(dst); !bytes.Equal(got, data) {
+		t.Errorf("downloaded %q, want %q", got, data)
+	}
+}
+
+func TestDownloadErrors(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	c := &Client{CacheDir: "off", BaseURL: srv.URL}
+	dst := filepath.Join(t.TempDir(), "go.tar.gz")
+	if err := c.Download(context.Background(), "go.tar.gz", dst, nil); err == nil {
+		t.Errorf("Download of missing file succeeded")
+	}
+	if _, err := os.Stat(dst); !os.IsNotExist(err) {
+		t.Errorf("Download of missing file created %s", dst)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c = newDownloadClient(t, []byte("data"), nil)
+	if err := c.Download(ctx, "go.tar.gz", dst, nil); err == nil {
+		t.Errorf("Download with canceled context succeeded")
+	}
+
+	c = &Client{Offline: true}
+	if err := c.Download(context.Background(), "go.tar.gz", dst, nil); err == nil {
+		t.Errorf("offline Download succeeded")
+	}
+}
@@ -0,0 +1,425 @@
+package gover
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MarshalText implements [encoding.TextMarshaler], returning the
+// conventional name of v, as returned by [Version.Canonical],
+// such as "go1.21.3" or "go1.20". The zero Version marshals as empty text.
+// Together with UnmarshalText, this lets a Version be used directly with
+// packages that honor those interfaces, as with [flag.TextVar]:
+//
+//	var min gover.Version
+//	flag.TextVar(&min, "min", gover.MustParse("go1.21"), "minimum Go version")
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.Canonical()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler], parsing a version
+// in any form accepted by [ParseRuntime], including the output of
+// MarshalText, and rejecting invalid versions with a [*ParseError].
+// Empty text unmarshals as the zero Version, so that the zero Version
+// round-trips through MarshalText.
+func (v *Version) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = Version{}
+		return nil
+	}
+	x, err := ParseRuntime(string(text))
+	if err != nil {
+		return err
+	}
+	*v = x
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler], encoding v as a JSON string
+// holding the text returned by [Version.MarshalText],
+// such as "go1.21.3" or "go1.20". The zero Version encodes as "".
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Canonical())
+}
+
+// UnmarshalJSON implements [json.Unmarshaler], decoding a JSON string
+// as with [Version.UnmarshalText], so that invalid versions are rejected
+// with a [*ParseError]. The empty string decodes as the zero Version,
+// and null leaves v unchanged.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("gover: version must be a JSON string: %v", err)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Value implements [driver.Valuer], storing v in a database column as the
+// text returned by [Version.MarshalText], such as "go1.21.3".
+// The zero Version is stored as NULL.
+// To sort by version in queries, store [Version.SortKey] alongside.
+func (v Version) Value() (driver.Value, error) {
+	if v == (Version{}) {
+		return nil, nil
+	}
+	return v.Canonical(), nil
+}
+
+// Scan implements [database/sql.Scanner], parsing a string or []byte
+// column value as with [Version.UnmarshalText].
+// NULL scans as the zero Version.
+func (v *Version) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*v = Version{}
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(src))
+	case []byte:
+		return v.UnmarshalText(src)
+	}
+	return fmt.Errorf("gover: cannot scan %T into Version", src)
+}
+
+// SortKey returns a string that sorts bytewise as v sorts among other
+// versions, as compared by [Version.Compare], for use as an ORDER BY
+// column or an index in a database or key-value store. Columns must use
+// a bytewise collation, such as COLLATE "C" in PostgreSQL or a binary
+// collation in MySQL; the key uses only ASCII digits, lower-case letters,
+// ".", and "~".
+// Versions that compare equal, such as those differing only in their
+// suffixes, have equal keys; the zero Version has the empty key, which
+// sorts first. SortKey returns the empty string as well if a number in
+// v has more than 99 digits.
+func (v Version) SortKey() string {
+	if v == (Version{}) {
+		return ""
+	}
+	var b strings.Builder
+	for _, n := range [...]string{v.Major, v.Minor, v.Patch} {
+		if !sortKeyInt(&b, n) {
+			return ""
+		}
+	}
+	// A language version, without a patch number, sorts before its
+	// prereleases, which sort before any release.
+	switch {
+	case v.Kind != "":
+		b.WriteString(v.Kind + ".")
+	case v.Patch == "":
+		b.WriteString(".")
+	default:
+		b.WriteString("~")
+	}
+	if !sortKeyInt(&b, v.Pre) {
+		return ""
+	}
+	return b.String()
+}
+
+// sortKeyInt writes the decimal number n, which may be empty, to b,
+// prefixed by its two-digit length so that longer numbers sort later.
+// It reports false if n has more than 99 digits.
+func sortKeyInt(b *strings.Builder, n string) bool {
+	if len(n) > 99 {
+		return false
+	}
+	fmt.Fprintf(b, "%02d%s", len(n), n)
+	return true
+}
+
+// The binary encoding of a non-zero Version is a format byte, binaryFormat,
+// followed by a byte of binaryFlags saying which fields are present, and then:
+//
+//   - Major, Minor, and Patch, in that order;
+//   - if Kind is set, Kind and Pre;
+//   - Suffix, Commit, and Experiment, each only if set.
+//
+// Strings are encoded as a uvarint length followed by their bytes.
+// Numbers, including Pre, are encoded as the uvarint 0 if absent or n+1
+// for the number n, unless binaryDecimal is set, in which case they are
+// encoded as strings of decimal digits, allowing numbers that do not fit
+// in a uint64. The zero Version encodes as no bytes at all.
+const binaryFormat = 1
+
+const (
+	binaryDevel      = 1 << iota // Devel is set
+	binaryKind                   // Kind and Pre follow the numbers
+	binarySuffix                 // Suffix follows
+	binaryCommit                 // Commit follows
+	binaryExperiment             // Experiment follows
+	binaryDecimal                // numbers are encoded as decimal strings
+)
+
+// MarshalBinary implements [encoding.BinaryMarshaler], encoding v in a
+// compact binary form that [Version.UnmarshalBinary] decodes without
+// parsing version text, for use in gob streams, caches, and other
+// binary protocols. The form begins with a format number, so that later
+// releases of this package can extend it while still decoding older data.
+// Go 1.21.3 encodes in 5 bytes.
+// MarshalBinary returns an error if v is not a version that ParseRuntime
+// could return with a prerelease kind of alpha, beta, or rc,
+// since UnmarshalBinary would reject it.
+func (v Version) MarshalBinary() ([]byte, error) {
+	if v == (Version{}) {
+		return []byte{}, nil
+	}
+	if !binaryValid(v) {
+		return nil, fmt.Errorf("gover: MarshalBinary: invalid version %#v", v)
+	}
+	var flags byte
+	if v.Devel {
+		flags |= binaryDevel
+	}
+	if v.Kind != "" {
+		flags |= binaryKind
+	}
+	if v.Suffix != "" {
+		flags |= binarySuffix
+	}
+	if v.Commit != "" {
+		flags |= binaryCommit
+	}
+	if v.Experiment != "" {
+		flags |= binaryExperiment
+	}
+	nums := []string{v.Major, v.Minor, v.Patch}
+	if v.Kind != "" {
+		nums = append(nums, v.Pre)
+	}
+	for _, n := range nums {
+		if x, err := strconv.ParseUint(n, 10, 64); n != "" && (err != nil || x == 1<<64-1) {
+			flags |= binaryDecimal
+		}
+	}
+
+	b := []byte{binaryFormat, flags}
+	appendNum := func(n string) {
+		if flags&binaryDecimal != 0 {
+			b = appendBinaryString(b, n)
+			return
+		}
+		x := uint64(0)
+		if n != "" {
+			x, _ = strconv.ParseUint(n, 10, 64)
+			x++
+		}
+		b = binary.AppendUvarint(b, x)
+	}
+	appendNum(v.Major)
+	appendNum(v.Minor)
+	appendNum(v.Patch)
+	if v.Kind != "" {
+		b = appendBinaryString(b, v.Kind)
+		appendNum(v.Pre)
+	}
+	for _, s := range []string{v.Suffix, v.Commit, v.Experiment} {
+		if s != "" {
+			b = appendBinaryString(b, s)
+		}
+	}
+	return b, nil
+}
+
+// appendBinaryString appends s to b, preceded by its length.
+func appendBinaryString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+// errBinary reports malformed binary data.
+var errBinary = errors.New("gover: UnmarshalBinary: malformed data")
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler], decoding data
+// produced by [Version.MarshalBinary]. It returns an error if data is
+// malformed, uses an unknown format, or describes a version that
+// MarshalBinary would not encode.
+func (v *Version) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		*v = Version{}
+		return nil
+	}
+	if data[0] != binaryFormat {
+		return fmt.Errorf("gover: UnmarshalBinary: unknown format %d", data[0])
+	}
+	if len(data) < 2 || data[1] >= binaryDecimal<<1 {
+		return errBinary
+	}
+	flags, rest := data[1], data[2:]
+	ok := true
+	str := func() string {
+		n, k := binary.Uvarint(rest)
+		if k <= 0 || n > uint64(len(rest)-k) {
+			ok = false
+			return ""
+		}
+		s := string(rest[k : k+int(n)])
+		rest = rest[k+int(n):]
+		return s
+	}
+	num := func() string {
+		if flags&binaryDecimal != 0 {
+			s := str()
+			if s != "" && !isDecimal(s) {
+				ok = false
+			}
+			return s
+		}
+		x, k := binary.Uvarint(rest)
+		if k <= 0 {
+			ok = false
+			return ""
+		}
+		rest = rest[k:]
+		if x == 0 {
+			return ""
+		}
+		return strconv.FormatUint(x-1, 10)
+	}
+
+	var x Version
+	x.Devel = flags&binaryDevel != 0
+	x.Major = num()
+	x.Minor = num()
+	x.Patch = num()
+	if flags&binaryKind != 0 {
+		x.Kind = str()
+		x.Pre = num()
+		if x.Kind == "" || strings.Trim(x.Kind, "abcdefghijklmnopqrstuvwxyz") != "" {
+			ok = false
+		}
+	}
+	if flags&binarySuffix != 0 {
+		if x.Suffix = str(); x.Suffix == "" {
+			ok = false
+		}
+	}
+	if flags&binaryCommit != 0 {
+		if x.Commit = str(); x.Commit == "" {
+			ok = false
+		}
+	}
+	if flags&binaryExperiment != 0 {
+		if x.Experiment = str(); x.Experiment == "" {
+			ok = false
+		}
+	}
+	if !ok || len(rest) != 0 || !binaryValid(x) {
+		return errBinary
+	}
+	*v = x
+	return nil
+}
+
+// binaryValid reports whether the non-zero Version x is one that
+// ParseRuntime can return, and so formats as text that parses back as x,
+// with a prerelease kind, if any, of alpha, beta, or rc.
+// It checks the fields directly, without formatting and parsing x.
+func binaryValid(x Version) bool {
+	for _, n := range []string{x.Major, x.Minor, x.Patch, x.Pre} {
+		if n != "" && !isDecimal(n) {
+			return false
+		}
+	}
+	switch {
+	case x.Major == "" || x.Minor == "":
+		// Parse fills in the minor version, as in go1 for go1.0.0.
+		return false
+	case x.Patch == "" && x.Kind == "" && !UsesExplicitPatchZero(x.Minor):
+		// Before Go 1.21, Parse fills in the patch version of a release.
+		return false
+	case x.Pre != "" && x.Kind == "":
+		return false
+	}
+	switch x.Kind {
+	case "", "alpha", "beta", "rc":
+	default:
+		return false
+	}
+	if x.Devel {
+		// ParseRuntime moves the suffix of a development version to Commit,
+		// and ends the version at the first space.
+		if x.Suffix != "" || strings.ContainsAny(x.Commit, " \t\n\r\v\f") {
+			return false
+		}
+	} else if x.Commit != "" {
+		return false
+	}
+	// ParseRuntime ends the version at the first " X:", which begins
+	// the experiments.
+	return !strings.Contains(x.Suffix, " X:") && !strings.Contains(x.Commit, " X:")
+}
+
+// MarshalYAML implements the Marshaler interface of gopkg.in/yaml.v2 and
+// gopkg.in/yaml.v3, encoding v as a string holding the text returned by
+// [Version.MarshalText], such as "go1.21.3". Those packages would use
+// MarshalText anyway; MarshalYAML makes the encoding explicit and
+// consistent with UnmarshalYAML.
+func (v Version) MarshalYAML() (any, error) {
+	return v.Canonical(), nil
+}
+
+// UnmarshalYAML implements the Unmarshaler interface of gopkg.in/yaml.v2,
+// which gopkg.in/yaml.v3 also honors, decoding a scalar as with
+// [Version.UnmarshalText]. Because YAML files such as CI workflows
+// commonly write Go versions as bare numbers, as in "go: 1.21",
+// a version without the "go" prefix is accepted as well; YAML would
+// otherwise read it as a number, but the packages pass the number as
+// written, so "1.20" does not lose its trailing zero.
+// Empty and null values decode as the zero Version.
+//
+// Packages such as sigs.k8s.io/yaml that convert YAML to JSON instead use
+// [Version.UnmarshalJSON], which requires a string: with them, write
+// versions with the "go" prefix, or quote them.
+func (v *Version) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	if s != "" && '0' <= s[0] && s[0] <= '9' {
+		s = "go" + s
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// MarshalTOML implements the Marshaler interface of
+// github.com/BurntSushi/toml, encoding v as a TOML string holding the
+// text returned by [Version.MarshalText], as in min_go = "go1.21".
+// Other TOML packages, such as github.com/pelletier/go-toml/v2, use
+// MarshalText directly.
+func (v Version) MarshalTOML() ([]byte, error) {
+	return tomlString(v.Canonical())
+}
+
+// UnmarshalTOML implements the Unmarshaler interface of
+// github.com/BurntSushi/toml, decoding a TOML string as with
+// [Version.UnmarshalText]. Other TOML values, notably unquoted numbers
+// such as min_go = 1.20, which TOML reads as the float 1.2, are rejected.
+func (v *Version) UnmarshalTOML(data any) error {
+	s, ok := data.(string)
+	if !ok {
+		return fmt.Errorf("gover: version must be a TOML string, as in \"go1.21\", not %T", data)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// tomlString returns s encoded as a TOML basic string.
+// JSON string syntax is a subset of TOML's basic strings.
+func tomlString(s string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,353 @@
+package gover
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// encodingTests are versions and their encoded forms.
+var encodingTests = []struct {
+	in   string // as accepted by ParseRuntime, or "" for the zero Version
+	text string
+}{
+	{"go1.21.3", "go1.21.3"},
+	{"go1.20", "go1.20"},
+	{"go1.20.0", "go1.20"},
+	{"go1", "go1"},
+	{"go1.21", "go1.21"},
+	{"go1.21.0", "go1.21.0"},
+	{"go1.22rc2", "go1.22rc2"},
+	{"go1.21.3-bigcorp", "go1.21.3-bigcorp"},
+	{"go1.21.0 X:loopvar", "go1.21.0 X:loopvar"},
+	{"devel go1.23-2b1f1e8 Tue Jul 2 18:27:20 2024 +0000", "devel go1.23-2b1f1e8"},
+	{"", ""},
+}
+
+// encodingVersion returns the version parsed from the in field of an encodingTests entry.
+func encodingVersion(t *testing.T, in string) Version {
+	t.Helper()
+	if in == "" {
+		return Version{}
+	}
+	v, err := ParseRuntime(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestVersionJSON(t *testing.T) {
+	for _, tt := range encodingTests {
+		v := encodingVersion(t, tt.in)
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Errorf("json.Marshal(%q): %v", tt.in, err)
+			continue
+		}
+		want, _ := json.Marshal(tt.text)
+		if string(data) != string(want) {
+			t.Errorf("json.Marshal(%q) = %s, want %s", tt.in, data, want)
+		}
+		var back Version
+		if err := json.Unmarshal(data, &back); err != nil || back != v {
+			t.Errorf("json.Unmarshal(%s) = %#v, %v, want %#v", data, back, err, v)
+		}
+	}
+
+	type config struct {
+		Min Version  `json:"min"`
+		Max *Version `json:"max"`
+	}
+	var c config
+	if err := json.Unmarshal([]byte(`{"min": "go1.22.1", "max": null}`), &c); err != nil || c.Min != MustParse("go1.22.1") || c.Max != nil {
+		t.Errorf("json.Unmarshal(config) = %+v, %v", c, err)
+	}
+	c.Min = MustParse("go1.21")
+	if err := json.Unmarshal([]byte(`{"min": null}`), &c); err != nil || c.Min != MustParse("go1.21") {
+		t.Errorf("json.Unmarshal(null) changed version to %v, %v", c.Min, err)
+	}
+
+	var pe *ParseError
+	for _, data := range []string{`"1.21.3"`, `"go1.21.x"`, `"devel +2b1f1e8"`, `121`, `["go1.21"]`} {
+		var v Version
+		err := json.Unmarshal([]byte(data), &v)
+		if err == nil {
+			t.Errorf("json.Unmarshal(%s) = %v, want error", data, v)
+		}
+		if data == `"1.21.3"` && !errors.As(err, &pe) {
+			t.Errorf("json.Unmarshal(%s) error = %T, want *ParseError", data, err)
+		}
+	}
+}
+
+func TestVersionText(t *testing.T) {
+	var _ encoding.TextMarshaler = Version{}
+	var _ encoding.TextUnmarshaler = new(Version)
+
+	for _, tt := range encodingTests {
+		v := encodingVersion(t, tt.in)
+		text, err := v.MarshalText()
+		if string(text) != tt.text || err != nil {
+			t.Errorf("MarshalText(%q) = %q, %v, want %q", tt.in, text, err, tt.text)
+		}
+		back := MustParse("go1.99")
+		if err := back.UnmarshalText(text); err != nil || back != v {
+			t.Errorf("UnmarshalText(%q) = %#v, %v, want %#v", text, back, err, v)
+		}
+	}
+
+	var pe *ParseError
+	for _, text := range []string{"1.21.3", "go1.21.x", "go1.21.3 ", "latest"} {
+		var v Version
+		if err := v.UnmarshalText([]byte(text)); !errors.As(err, &pe) {
+			t.Errorf("UnmarshalText(%q) = %v, want *ParseError", text, err)
+		}
+	}
+
+	// Packages honoring the interfaces.
+	type doc struct {
+		Go Version `xml:"go,attr"`
+	}
+	data, err := xml.Marshal(doc{MustParse("go1.22.1")})
+	if want := `<doc go="go1.22.1"></doc>`; string(data) != want || err != nil {
+		t.Errorf("xml.Marshal = %s, %v, want %s", data, err, want)
+	}
+	var d doc
+	if err := xml.Unmarshal(data, &d); err != nil || d.Go != MustParse("go1.22.1") {
+		t.Errorf("xml.Unmarshal(%s) = %v, %v", data, d.Go, err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var min Version
+	fs.TextVar(&min, "min", MustParse("go1.21"), "minimum Go version")
+	if min != MustParse("go1.21") {
+		t.Errorf("flag default = %v, want go1.21", min)
+	}
+	if err := fs.Parse([]string{"-min=go1.22rc1"}); err != nil || min != MustParse("go1.22rc1") {
+		t.Errorf("-min=go1.22rc1 gives %v, %v", min, err)
+	}
+	if err := fs.Parse([]string{"-min=1.22"}); err == nil {
+		t.Errorf("-min=1.22 succeeded")
+	}
+}
+
+func TestVersionSQL(t *testing.T) {
+	for _, tt := range encodingTests {
+		v := encodingVersion(t, tt.in)
+		val, err := v.Value()
+		if err != nil {
+			t.Errorf("Value(%q): %v", tt.in, err)
+			continue
+		}
+		if tt.in == "" {
+			if val != nil {
+				t.Errorf("Value(zero Version) = %#v, want nil", val)
+			}
+		} else if val != tt.text {
+			t.Errorf("Value(%q) = %#v, want %q", tt.in, val, tt.text)
+		}
+		for _, src := range []any{val, []byte(tt.text)} {
+			back := MustParse("go1.99")
+			if err := back.Scan(src); err != nil || back != v {
+				t.Errorf("Scan(%#v) = %#v, %v, want %#v", src, back, err, v)
+			}
+		}
+	}
+	var v Version
+	for _, src := range []any{"1.21", []byte("bad"), 121, 1.21} {
+		if err := v.Scan(src); err == nil {
+			t.Errorf("Scan(%#v) = %v, want error", src, v)
+		}
+	}
+}
+
+func TestVersionSortKey(t *testing.T) {
+	vs := []string{
+		"go1", "go1.9beta1", "go1.9rc1", "go1.9", "go1.9.2rc2", "go1.9.2", "go1.10",
+		"go1.20rc1", "go1.20", "go1.20.14", "go1.21", "go1.21alpha1", "go1.21beta1", "go1.21rc1",
+		"go1.21rc2", "go1.21rc10", "go1.21.0", "go1.21.3", "go1.21.10", "go1.99999999999", "go2", "go10",
+	}
+	shuffled := slices.Clone(vs)
+	rand.New(rand.NewSource(1)).Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	slices.SortFunc(shuffled, func(x, y string) int { return strings.Compare(MustParse(x).SortKey(), MustParse(y).SortKey()) })
+	if !slices.Equal(shuffled, vs) {
+		t.Errorf("sorted by SortKey:\n%v\nwant:\n%v", shuffled, vs)
+	}
+	for i, x := range vs {
+		for _, y := range vs[i:] {
+			if got, want := strings.Compare(MustParse(x).SortKey(), MustParse(y).SortKey()), Compare(x, y); got != want {
+				t.Errorf("SortKey(%s) vs SortKey(%s) = %d, want %d", x, y, got, want)
+			}
+		}
+	}
+	if a, b := MustParse("go1.21.3-bigcorp").SortKey(), MustParse("go1.21.3").SortKey(); a != b {
+		t.Errorf("SortKey(go1.21.3-bigcorp) = %q, SortKey(go1.21.3) = %q, want equal", a, b)
+	}
+	if k := (Version{}).SortKey(); k != "" {
+		t.Errorf("SortKey(zero Version) = %q, want \"\"", k)
+	}
+	if k := MustParse("go1." + strings.Repeat("9", 100)).SortKey(); k != "" {
+		t.Errorf("SortKey with 100-digit number = %q, want \"\"", k)
+	}
+}
+
+func TestVersionBinary(t *testing.T) {
+	big := Version{Major: "1", Minor: "99999999999999999999999", Patch: "1", Kind: "rc", Pre: "2"}
+	vs := []Version{big, MustParse("go1.21rc"), {Major: "1", Minor: "18446744073709551615"}}
+	for _, tt := range encodingTests {
+		vs = append(vs, encodingVersion(t, tt.in))
+	}
+	for _, v := range vs {
+		data, err := v.MarshalBinary()
+		if err != nil {
+			t.Errorf("MarshalBinary(%#v): %v", v, err)
+			continue
+		}
+		back := MustParse("go1.99")
+		if err := back.UnmarshalBinary(data); err != nil || back != v {
+			t.Errorf("UnmarshalBinary(MarshalBinary(%#v)) = %#v, %v", v, back, err)
+		}
+		if p, err := ParseRuntime(back.String()); v != (Version{}) && (err != nil || p != back) {
+			t.Errorf("ParseRuntime(%q) = %#v, %v, want %#v", back, p, err, back)
+		}
+	}
+	if data, _ := MustParse("go1.21.3").MarshalBinary(); len(data) != 5 {
+		t.Errorf("MarshalBinary(go1.21.3) = %v, want 5 bytes", data)
+	}
+	for _, v := range []Version{
+		{Major: "1", Minor: "x"},
+		{Major: "1", Minor: "21", Patch: "0", Kind: "x", Pre: "1"},
+		{Major: "1", Minor: "21", Patch: "3", Commit: "2b1f1e8"},
+		{Major: "1", Minor: "20"},
+	} {
+		if _, err := v.MarshalBinary(); err == nil {
+			t.Errorf("MarshalBinary(%#v) succeeded, want error", v)
+		}
+	}
+
+	// gob uses the binary form.
+	type record struct {
+		Name string
+		Go   Version
+	}
+	var buf bytes.Buffer
+	in := record{"toolchain", MustParse("go1.22.1-bigcorp")}
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil || out != in {
+		t.Errorf("gob round trip = %+v, %v, want %+v", out, err, in)
+	}
+
+	good, _ := MustParse("go1.21.3-bigcorp").MarshalBinary()
+	for _, data := range [][]byte{
+		{2, 0, 2, 22, 4},                             // unknown format
+		{1},                                          // missing flags
+		{1, 0x40, 2, 22, 4},                          // unknown flag
+		{1, 0, 2, 22},                                // missing patch
+		{1, 0, 2, 22, 4, 0},                          // trailing data
+		{1, 0, 0, 22, 4},                             // missing major
+		{1, 0, 2, 0, 4},                              // patch without minor
+		{1, 2, 2, 22, 1, 0},                          // empty kind
+		{1, 2, 2, 22, 1, 1, 'R', 0},                  // upper-case kind
+		{1, 4, 2, 22, 4, 0},                          // empty suffix
+		{1, 32, 1, '1', 2, '0', '2', 0},              // leading zero
+		{1, 0, 0x80},                                 // truncated uvarint
+		{1, 2, 2, 22, 1, 1, 'x', 2},                  // unknown kind
+		{1, 0, 2, 21, 0},                             // go1.20 without patch
+		{1, 8, 2, 22, 4, 3, 'a', ' ', 'b'},           // commit of release
+		{1, 9, 2, 24, 1, 3, 'a', ' ', 'b'},           // commit with space
+		{1, 5, 2, 24, 1, 1, 'x'},                     // suffix of development version
+		{1, 4, 2, 22, 4, 5, 'a', ' ', 'X', ':', 'b'}, // suffix with experiments
+		good[:len(good)-1],                           // truncated string
+	} {
+		var v Version
+		if err := v.UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(%v) = %#v, want error", data, v)
+		}
+	}
+}
+
+// yamlScalar returns an unmarshal function, as passed to UnmarshalYAML
+// by the yaml packages, decoding the scalar written as text.
+func yamlScalar(text string) func(any) error {
+	return func(out any) error {
+		s, ok := out.(*string)
+		if !ok {
+			return fmt.Errorf("cannot unmarshal into %T", out)
+		}
+		*s = text
+		return nil
+	}
+}
+
+func TestVersionYAML(t *testing.T) {
+	for _, tt := range encodingTests {
+		v := encodingVersion(t, tt.in)
+		out, err := v.MarshalYAML()
+		if out != tt.text || err != nil {
+			t.Errorf("MarshalYAML(%q) = %#v, %v, want %q", tt.in, out, err, tt.text)
+		}
+		back := MustParse("go1.99")
+		if err := back.UnmarshalYAML(yamlScalar(tt.text)); err != nil || back != v {
+			t.Errorf("UnmarshalYAML(%q) = %#v, %v, want %#v", tt.text, back, err, v)
+		}
+	}
+	for text, want := range map[string]string{"1.20": "go1.20", "1.21": "go1.21", "1.22.3": "go1.22.3", "1.23rc1": "go1.23rc1"} {
+		var v Version
+		if err := v.UnmarshalYAML(yamlScalar(text)); err != nil || v != MustParse(want) {
+			t.Errorf("UnmarshalYAML(%s) = %v, %v, want %s", text, v, err, want)
+		}
+	}
+	var pe *ParseError
+	for _, text := range []string{"1.2.3.4", "v1.21", "1.21.x", "stable"} {
+		var v Version
+		if err := v.UnmarshalYAML(yamlScalar(text)); !errors.As(err, &pe) {
+			t.Errorf("UnmarshalYAML(%q) = %v, want *ParseError", text, err)
+		}
+	}
+	var v Version
+	if err := v.UnmarshalYAML(func(any) error { return errors.New("not a scalar") }); err == nil {
+		t.Errorf("UnmarshalYAML of non-scalar succeeded")
+	}
+}
+
+func TestVersionTOML(t *testing.T) {
+	for _, tt := range encodingTests {
+		v := encodingVersion(t, tt.in)
+		data, err := v.MarshalTOML()
+		if want := `"` + tt.text + `"`; string(data) != want || err != nil {
+			t.Errorf("MarshalTOML(%q) = %s, %v, want %s", tt.in, data, err, want)
+		}
+		back := MustParse("go1.99")
+		if err := back.UnmarshalTOML(tt.text); err != nil || back != v {
+			t.Errorf("UnmarshalTOML(%q) = %#v, %v, want %#v", tt.text, back, err, v)
+		}
+	}
+	var pe *ParseError
+	var v Version
+	if err := v.UnmarshalTOML("1.21"); !errors.As(err, &pe) {
+		t.Errorf("UnmarshalTOML(\"1.21\") = %v, want *ParseError", err)
+	}
+	for _, data := range []any{1.2, int64(1), true, nil} {
+		if err := v.UnmarshalTOML(data); err == nil {
+			t.Errorf("UnmarshalTOML(%#v) succeeded, want error", data)
+		}
+	}
+	if data, err := tomlString("a\"b\\c\n<>"); string(data) != `"a\"b\\c\n<>"` || err != nil {
+		t.Errorf("tomlString = %s, %v", data, err)
+	}
+}
@@ -0,0 +1,236 @@
+package gover
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Extract extracts the Go distribution archive in the file archive,
+// a .zip file or a gzip-compressed tar file (.tar.gz or .tgz),
+// into the directory dir, creating dir if necessary.
+//
+// Extract guards against malicious or malformed archives:
+// it rejects entries whose names are absolute or lead outside dir,
+// symbolic links whose targets lead outside dir, entries that would be
+// written through a symbolic link, and entries other than regular files,
+// directories, and symbolic links.
+// It also normalizes permissions, creating directories with mode 0o755
+// and files with mode 0o755 if they are executable or 0o644 otherwise,
+// so that no setuid, setgid, or world-writable files are created.
+// Extract does not overwrite existing files.
+// If it returns an error, dir may contain a partial extraction.
+func Extract(archive, dir string) error {
+	x := &extractor{dir: dir, links: make(map[string]bool), traversed: make(map[string]bool)}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	var err error
+	switch {
+	case strings.HasSuffix(archive, ".zip"):
+		err = x.zip(archive)
+	case strings.HasSuffix(archive, ".tar.gz"), strings.HasSuffix(archive, ".tgz"):
+		err = x.tarGz(archive)
+	default:
+		return fmt.Errorf("extracting %s: unknown archive format", archive)
+	}
+	if err != nil {
+		return fmt.Errorf("extracting %s: %v", archive, err)
+	}
+	return nil
+}
+
+// An extractor writes the entries of an archive into a directory.
+type extractor struct {
+	dir       string
+	links     map[string]bool // slash-separated names of symbolic links created
+	traversed map[string]bool // directories that the targets of those links pass through
+}
+
+func (x *extractor) tarGz(archive string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(zr)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch h.Typeflag {
+		case tar.TypeDir:
+			err = x.mkdir(h.Name)
+		case tar.TypeReg:
+			err = x.file(h.Name, h.FileInfo().Mode(), tr)
+		case tar.TypeSymlink:
+			err = x.symlink(h.Name, h.Linkname)
+		case tar.TypeXGlobalHeader:
+			// Metadata only.
+		default:
+			err = fmt.Errorf("%s: unsupported entry type %q", h.Name, h.Typeflag)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (x *extractor) zip(archive string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	for _, zf := range zr.File {
+		if err := x.zipFile(zf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *extractor) zipFile(zf *zip.File) error {
+	mode := zf.Mode()
+	if mode.IsDir() || strings.HasSuffix(zf.Name, "/") {
+		return x.mkdir(zf.Name)
+	}
+	if mode.Type() != 0 && mode.Type() != fs.ModeSymlink {
+		return fmt.Errorf("%s: unsupported file mode %v", zf.Name, mode)
+	}
+	r, err := zf.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if mode&fs.ModeSymlink != 0 {
+		target, err := io.ReadAll(io.LimitReader(r, 4096))
+		if err != nil {
+			return err
+		}
+		return x.symlink(zf.Name, string(target))
+	}
+	return x.file(zf.Name, mode, r)
+}
+
+// path returns the file path at which to create the entry with the given name.
+func (x *extractor) path(name string) (string, error) {
+	clean := path.Clean(strings.TrimSuffix(name, "/"))
+	if name == "" || !fs.ValidPath(clean) || strings.Contains(name, `\`) || clean == "." {
+		return "", fmt.Errorf("%s: invalid file name", name)
+	}
+	for dir := path.Dir(clean); dir != "."; dir = path.Dir(dir) {
+		if x.links[dir] {
+			return "", fmt.Errorf("%s: file in symbolic link %s", name, dir)
+		}
+	}
+	return filepath.Join(x.dir, filepath.FromSlash(clean)), nil
+}
+
+func (x *extractor) mkdir(name string) error {
+	p, err := x.path(name)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(p, 0o755)
+}
+
+func (x *extractor) file(name string, mode fs.FileMode, r io.Reader) error {
+	p, err := x.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	perm := fs.FileMode(0o644)
+	if mode&0o111 != 0 {
+		perm = 0o755
+	}
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (x *extractor) symlink(name, target string) error {
+	p, err := x.path(name)
+	if err != nil {
+		return err
+	}
+	clean := path.Clean(strings.TrimSuffix(name, "/"))
+	if x.traversed[clean] {
+		// An earlier link's target was checked assuming this was a directory.
+		return fmt.Errorf("%s: symbolic link in the target of another symbolic link", name)
+	}
+	traversed, ok := x.inside(path.Dir(clean), target)
+	if !ok {
+		return fmt.Errorf("%s: symbolic link to %s leads outside the archive", name, target)
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	if err := os.Symlink(filepath.FromSlash(target), p); err != nil {
+		return err
+	}
+	x.links[clean] = true
+	for _, dir := range traversed {
+		x.traversed[dir] = true
+	}
+	return nil
+}
+
+// inside reports whether the symbolic link target, relative to the
+// directory dir, names a file inside the archive. Because ".." in a target
+// is relative to where earlier elements resolve, the target must not pass
+// through another symbolic link. So that no later link can do so either,
+// inside also returns the directories the target passes through.
+func (x *extractor) inside(dir, target string) (traversed []string, ok bool) {
+	if target == "" || path.IsAbs(target) || strings.Contains(target, `\`) {
+		return nil, false
+	}
+	var elems []string
+	if dir != "." {
+		elems = strings.Split(dir, "/")
+	}
+	for _, elem := range strings.Split(target, "/") {
+		if len(elems) > 0 {
+			cur := strings.Join(elems, "/")
+			if x.links[cur] {
+				return nil, false
+			}
+			traversed = append(traversed, cur)
+		}
+		switch elem {
+		case "", ".":
+		case "..":
+			if len(elems) == 0 {
+				return nil, false
+			}
+			elems = elems[:len(elems)-1]
+		default:
+			elems = append(elems, elem)
+		}
+	}
+	return traversed, true
+}
@@ -0,0 +1,180 @@
+package gover
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// An archiveEntry describes an entry of a test archive.
+type archiveEntry struct {
+	name string
+	mode fs.FileMode
+	data string // file contents or symbolic link target
+}
+
+func writeTarGz(t *testing.T, file string, entries []archiveEntry) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, e := range entries {
+		h := &tar.Header{Name: e.name, Mode: int64(e.mode.Perm())}
+		switch {
+		case e.mode&fs.ModeDir != 0:
+			h.Typeflag = tar.TypeDir
+		case e.mode&fs.ModeSymlink != 0:
+			h.Typeflag = tar.TypeSymlink
+			h.Linkname = e.data
+		case e.mode&fs.ModeNamedPipe != 0:
+			h.Typeflag = tar.TypeFifo
+		default:
+			h.Typeflag = tar.TypeReg
+			h.Size = int64(len(e.data))
+		}
+		if e.mode&fs.ModeSetuid != 0 {
+			h.Mode |= 0o4000
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+		if h.Typeflag == tar.TypeReg {
+			tw.Write([]byte(e.data))
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zw.Close()
+	if err := os.WriteFile(file, buf.Bytes(), 0o666); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeZip(t *testing.T, file string, entries []archiveEntry) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		h := &zip.FileHeader{Name: e.name}
+		h.SetMode(e.mode)
+		w, err := zw.CreateHeader(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.mode&fs.ModeDir == 0 {
+			w.Write([]byte(e.data))
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, buf.Bytes(), 0o666); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var goodArchive = []archiveEntry{
+	{"go/", fs.ModeDir | 0o777, ""},
+	{"go/VERSION", 0o666, "go1.21.3\n"},
+	{"go/bin/go", 0o777 | fs.ModeSetuid, "#!/bin/sh\n"},
+	{"go/src/a/b.go", 0o600, "package a\n"},
+	{"go/misc/link", fs.ModeSymlink | 0o777, "../src/a"},
+}
+
+var badArchives = []struct {
+	name    string
+	entries []archiveEntry
+}{
+	{"dotdot", []archiveEntry{{"go/../../evil", 0o644, "x"}}},
+	{"absolute", []archiveEntry{{"/tmp/evil", 0o644, "x"}}},
+	{"backslash", []archiveEntry{{`go\..\..\evil`, 0o644, "x"}}},
+	{"absolute link", []archiveEntry{{"go/link", fs.ModeSymlink | 0o777, "/etc"}}},
+	{"escaping link", []archiveEntry{{"go/link", fs.ModeSymlink | 0o777, "../.."}}},
+	{"write through link", []archiveEntry{
+		{"go/link", fs.ModeSymlink | 0o777, "."},
+		{"go/link/evil", 0o644, "x"},
+	}},
+	{"link through link", []archiveEntry{
+		{"go/a/link", fs.ModeSymlink | 0o777, ".."},
+		{"go/evil", fs.ModeSymlink | 0o777, "a/link/../.."},
+	}},
+	{"link later made a link", []archiveEntry{
+		{"go/a", fs.ModeSymlink | 0o777, "b/../.."},
+		{"go/b", fs.ModeSymlink | 0o777, ".."},
+	}},
+	{"duplicate", []archiveEntry{{"go/x", 0o644, "x"}, {"go/x", 0o644, "y"}}},
+}
+
+func TestExtract(t *testing.T) {
+	for _, ext := range []string{".tar.gz", ".zip"} {
+		t.Run(ext, func(t *testing.T) {
+			tmp := t.TempDir()
+			archive := filepath.Join(tmp, "go"+ext)
+			if ext == ".zip" {
+				writeZip(t, archive, goodArchive)
+			} else {
+				writeTarGz(t, archive, goodArchive)
+			}
+			dir := filepath.Join(tmp, "out")
+			if err := Extract(archive, dir); err != nil {
+				t.Fatal(err)
+			}
+			perms := map[string]fs.FileMode{
+				"go":            fs.ModeDir | 0o755,
+				"go/VERSION":    0o644,
+				"go/bin/go":     0o755,
+				"go/src/a/b.go": 0o644,
+			}
+			for name, want := range perms {
+				info, err := os.Stat(filepath.Join(dir, name))
+				if err != nil {
+					t.Errorf("%s: %v", name, err)
+					continue
+				}
+				// The umask may clear further bits.
+				if have := info.Mode(); have&^want != 0 || have.Type() != want.Type() {
+					t.Errorf("%s: mode %v, want %v", name, have, want)
+				}
+			}
+			data, err := os.ReadFile(filepath.Join(dir, "go/misc/link/b.go"))
+			if string(data) != "package a\n" || err != nil {
+				t.Errorf("reading through symbolic link: %q, %v", data, err)
+			}
+		})
+	}
+}
+
+func TestExtractBad(t *testing.T) {
+	for _, ext := range []string{".tar.gz", ".zip"} {
+		for _, tt := range badArchives {
+			tmp := t.TempDir()
+			archive := filepath.Join(tmp, "go"+ext)
+			if ext == ".zip" {
+				writeZip(t, archive, tt.entries)
+			} else {
+				writeTarGz(t, archive, tt.entries)
+			}
+			dir := filepath.Join(tmp, "out", "sub")
+			if err := Extract(archive, dir); err == nil {
+				t.Errorf("%s%s: Extract succeeded, want error", tt.name, ext)
+			}
+			if _, err := os.Lstat(filepath.Join(tmp, "evil")); err == nil {
+				t.Errorf("%s%s: created file outside directory", tt.name, ext)
+			}
+		}
+	}
+
+	tmp := t.TempDir()
+	archive := filepath.Join(tmp, "go.tar.gz")
+	writeTarGz(t, archive, []archiveEntry{{"go/fifo", fs.ModeNamedPipe | 0o644, ""}})
+	if err := Extract(archive, filepath.Join(tmp, "out")); err == nil {
+		t.Errorf("Extract of named pipe succeeded, want error")
+	}
+	if err := Extract(filepath.Join(tmp, "go.rar"), filepath.Join(tmp, "out")); err == nil {
+		t.Errorf("Extract of .rar succeeded, want error")
+	}
+}
@@ -0,0 +1,54 @@
+package gover
+
+// A FilterOption changes how Filter and FilterInPlace treat invalid versions.
+type FilterOption struct {
+	onInvalid func(v string)
+}
+
+// OnInvalid returns a FilterOption that calls f for each invalid version.
+// By default, invalid versions are dropped silently.
+func OnInvalid(f func(v string)) FilterOption {
+	return FilterOption{onInvalid: f}
+}
+
+// Filter returns a new slice holding the versions that satisfy c,
+// in their original order. Invalid versions never satisfy a constraint
+// and are dropped; use [OnInvalid] to learn which they were.
+func Filter(versions []string, c Constraint, opts ...FilterOption) []string {
+	var out []string
+	for _, v := range versions {
+		if keep(v, c, opts) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// FilterInPlace is like Filter but reuses the storage of versions,
+// returning the filtered prefix of the slice.
+// Like [slices.DeleteFunc], it zeroes the elements between the new length
+// and the original length.
+func FilterInPlace(versions []string, c Constraint, opts ...FilterOption) []string {
+	out := versions[:0]
+	for _, v := range versions {
+		if keep(v, c, opts) {
+			out = append(out, v)
+		}
+	}
+	clear(versions[len(out):])
+	return out
+}
+
+// keep reports whether Filter should keep v,
+// reporting v to any OnInvalid callbacks in opts if it is invalid.
+func keep(v string, c Constraint, opts []FilterOption) bool {
+	if !IsValid(v) {
+		for _, o := range opts {
+			if o.onInvalid != nil {
+				o.onInvalid(v)
+			}
+		}
+		return false
+	}
+	return c.Check(v)
+}
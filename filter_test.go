@@ -0,0 +1,53 @@
+package gover
+
+import (
+	"slices"
+	"testing"
+)
+
+var filterTests = []struct {
+	constraint string
+	in         []string
+	out        []string
+	invalid    []string
+}{
+	{
+		">=go1.21, <go1.23",
+		[]string{"go1.20.14", "go1.21.0", "go1.22rc1", "bad", "go1.22.5", "go1.23.0", "go1.021"},
+		[]string{"go1.21.0", "go1.22rc1", "go1.22.5"},
+		[]string{"bad", "go1.021"},
+	},
+	{"go1.21.x || go1.23.x", []string{"go1.23.1", "go1.22.1", "go1.21.2"}, []string{"go1.23.1", "go1.21.2"}, nil},
+	{">=go1.25", []string{"go1.21.0", "go1.22.0"}, nil, nil},
+	{"*", nil, nil, nil},
+}
+
+func TestFilter(t *testing.T) {
+	for _, tt := range filterTests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var invalid []string
+		orig := slices.Clone(tt.in)
+		out := Filter(tt.in, c, OnInvalid(func(v string) { invalid = append(invalid, v) }))
+		if !slices.Equal(out, tt.out) || !slices.Equal(invalid, tt.invalid) {
+			t.Errorf("Filter(%q, %q) = %q, invalid %q, want %q, invalid %q", tt.in, tt.constraint, out, invalid, tt.out, tt.invalid)
+		}
+		if !slices.Equal(tt.in, orig) {
+			t.Errorf("Filter(%q, %q) modified its input", orig, tt.constraint)
+		}
+
+		in := slices.Clone(tt.in)
+		out = FilterInPlace(in, c)
+		if !slices.Equal(out, tt.out) {
+			t.Errorf("FilterInPlace(%q, %q) = %q, want %q", tt.in, tt.constraint, out, tt.out)
+		}
+		for _, v := range in[len(out):] {
+			if v != "" {
+				t.Errorf("FilterInPlace(%q, %q) left %q in tail", tt.in, tt.constraint, in[len(out):])
+				break
+			}
+		}
+	}
+}
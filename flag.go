@@ -0,0 +1,52 @@
+package gover
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VersionSlice is a list of Go versions that implements [flag.Value],
+// so that a command can accept a flag such as -go more than once:
+//
+//	var versions gover.VersionSlice
+//	flag.Var(&versions, "go", "Go version to test (repeatable)")
+//
+// Each call to Set appends one or more comma-separated versions in
+// canonical form, rejecting any that are not valid. VersionSlice also satisfies the
+// pflag.Value interface used by github.com/spf13/pflag.
+type VersionSlice []string
+
+// String returns the versions in the slice joined by commas.
+func (s *VersionSlice) String() string {
+	if s == nil {
+		return ""
+	}
+	return strings.Join(*s, ",")
+}
+
+// Set appends the comma-separated versions in value to the slice,
+// each in the canonical form returned by [Version.Canonical],
+// so that "go1.20.0" is stored as "go1.20".
+// If any version is invalid, Set returns an error and leaves the slice unchanged.
+func (s *VersionSlice) Set(value string) error {
+	var vs []string
+	for _, x := range strings.Split(value, ",") {
+		x = strings.TrimSpace(x)
+		if !IsValid(x) {
+			return fmt.Errorf("invalid version %q", x)
+		}
+		vs = append(vs, MustParse(x).Canonical())
+	}
+	*s = append(*s, vs...)
+	return nil
+}
+
+// Type returns the name of the flag type, for pflag.Value.
+func (s *VersionSlice) Type() string {
+	return "goversionSlice"
+}
+
+// Get returns the versions in the slice, for [flag.Getter].
+func (s *VersionSlice) Get() any {
+	return []string(*s)
+}
@@ -0,0 +1,54 @@
+package gover
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+var _ flag.Getter = (*VersionSlice)(nil)
+
+func TestVersionSlice(t *testing.T) {
+	var s VersionSlice
+	for _, x := range []string{"go1.21", "go1.22.3", "go1.20rc1,go1.19.0"} {
+		if err := s.Set(x); err != nil {
+			t.Fatalf("Set(%q): %v", x, err)
+		}
+	}
+	want := VersionSlice{"go1.21", "go1.22.3", "go1.20rc1", "go1.19"}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("after Set: %q, want %q", s, want)
+	}
+	if got, want := s.String(), "go1.21,go1.22.3,go1.20rc1,go1.19"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := s.Type(), "goversionSlice"; got != want {
+		t.Errorf("Type() = %q, want %q", got, want)
+	}
+
+	for _, x := range []string{"1.21", "go1.21,bad", ""} {
+		if err := s.Set(x); err == nil {
+			t.Errorf("Set(%q) succeeded, want error", x)
+		}
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("after failed Set: %q, want %q", s, want)
+	}
+}
+
+func TestVersionSliceFlagSet(t *testing.T) {
+	var s VersionSlice
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&s, "go", "")
+	if err := fs.Parse([]string{"-go=go1.21.0", "-go", "go1.22.1", "-go=go1.20.0"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.String(), "go1.21.0,go1.22.1,go1.20"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if err := fs.Parse([]string{"-go=go1.021"}); err == nil {
+		t.Errorf("Parse(-go=go1.021) succeeded, want error")
+	}
+}
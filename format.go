@@ -0,0 +1,55 @@
+package gover
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatTable formats a list of versions as a table, one version per line,
+// sorted using Compare, with the major, minor, and patch numbers
+// right-aligned in columns and any prerelease appended after them.
+// Invalid versions are listed after the valid ones, in their original order,
+// each on a line of the form `invalid "x"`.
+// For example, FormatTable([]string{"go1.21.10", "bad", "go1.9.2rc2", "go1.22rc1"}) returns
+//
+//	1  9  2 rc2
+//	1 21 10
+//	1 22    rc1
+//	invalid "bad"
+func FormatTable(versions []string) string {
+	var valid, invalid []string
+	for _, x := range versions {
+		if IsValid(x) {
+			valid = append(valid, x)
+		} else {
+			invalid = append(invalid, x)
+		}
+	}
+	Sort(valid)
+
+	rows := make([]Version, len(valid))
+	var wMajor, wMinor, wPatch int
+	for i, x := range valid {
+		v := parse(stripGo(x))
+		rows[i] = v
+		wMajor = max(wMajor, len(v.Major))
+		wMinor = max(wMinor, len(v.Minor))
+		wPatch = max(wPatch, len(v.Patch))
+	}
+
+	var b strings.Builder
+	for _, v := range rows {
+		line := fmt.Sprintf("%*s %*s %*s", wMajor, v.Major, wMinor, v.Minor, wPatch, v.Patch)
+		if v.Kind != "" {
+			line += " " + v.Kind + v.Pre
+		}
+		b.WriteString(strings.TrimRight(line, " "))
+		b.WriteByte('\n')
+	}
+	for _, x := range invalid {
+		b.WriteString("invalid " + strconv.Quote(x))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,22 @@
+package gover
+
+import "testing"
+
+func TestFormatTable(t *testing.T) {
+	in := []string{"go1.21.10", "bad", "go1.9.2rc2", "go1.22rc1", "go1.21", "go1.20", "1.21", "go1.100.1"}
+	want := "" +
+		"1   9  2 rc2\n" +
+		"1  20  0\n" +
+		"1  21\n" +
+		"1  21 10\n" +
+		"1  22    rc1\n" +
+		"1 100  1\n" +
+		"invalid \"bad\"\n" +
+		"invalid \"1.21\"\n"
+	if got := FormatTable(in); got != want {
+		t.Errorf("FormatTable(%q) =\n%s\nwant:\n%s", in, got, want)
+	}
+	if got := FormatTable(nil); got != "" {
+		t.Errorf("FormatTable(nil) = %q, want \"\"", got)
+	}
+}
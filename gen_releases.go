@@ -0,0 +1,111 @@
+//go:build ignore
+
+// Gen_releases regenerates releases.txt, the table of Go releases
+// embedded in the gover package.
+//
+// Release dates come from the release history at https://go.dev/doc/devel/release.
+// Releases that the history does not list yet come from the download
+// index at https://go.dev/dl/?mode=json&include=all and have no date.
+// Prereleases are omitted: the index lists only the latest ones,
+// so the table could not record them consistently.
+//
+// Usage:
+//
+//	go generate
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+
+	"github.com/Open-Source-CQUT/gover"
+)
+
+const (
+	historyURL = "https://go.dev/doc/devel/release"
+	indexURL   = "https://go.dev/dl/?mode=json&include=all"
+)
+
+// releasedRE matches a release in the release history, as in
+// "go1.21.1 (released 2023-09-06)".
+var releasedRE = regexp.MustCompile(`\b(go1(?:\.\d+){0,2})\s+\(released\s+(\d{4}-\d{2}-\d{2})\)`)
+
+type entry struct {
+	version, date string
+	stable        bool
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen_releases: ")
+
+	history, err := fetch(historyURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	seen := make(map[string]bool)
+	var entries []entry
+	for _, m := range releasedRE.FindAllSubmatch(history, -1) {
+		v := string(m[1])
+		if !gover.IsValid(v) || seen[v] {
+			continue
+		}
+		seen[v] = true
+		entries = append(entries, entry{v, string(m[2]), true})
+	}
+	if len(entries) == 0 {
+		log.Fatalf("no releases found in %s", historyURL)
+	}
+
+	index, err := fetch(indexURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var files []struct {
+		Version string `json:"version"`
+		Stable  bool   `json:"stable"`
+	}
+	if err := json.Unmarshal(index, &files); err != nil {
+		log.Fatalf("parsing %s: %v", indexURL, err)
+	}
+	for _, f := range files {
+		if !gover.IsValid(f.Version) || !f.Stable || seen[f.Version] {
+			continue
+		}
+		seen[f.Version] = true
+		entries = append(entries, entry{f.Version, "-", true})
+	}
+
+	slices.SortFunc(entries, func(x, y entry) int { return gover.Compare(x.version, y.version) })
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Code generated by gen_releases.go; DO NOT EDIT.\n#\n# version date stable\n")
+	for _, e := range entries {
+		stable := "unstable"
+		if e.stable {
+			stable = "stable"
+		}
+		fmt.Fprintf(&buf, "%s %s %s\n", e.version, e.date, stable)
+	}
+	if err := os.WriteFile("releases.txt", buf.Bytes(), 0o666); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func fetch(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
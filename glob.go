@@ -0,0 +1,19 @@
+package gover
+
+import "path"
+
+// MatchGlob reports whether the version v matches the shell pattern,
+// such as "go1.2*" or "go1.*.3". The pattern syntax is that of [path.Match]:
+// '*' matches any sequence of characters, '?' matches any single character,
+// and '[...]' matches a character class.
+// The pattern is matched against v as written, so "go1.21.*" matches
+// go1.21.0 but not the language version go1.21 or the prerelease go1.21rc1.
+// MatchGlob returns false if v is not a valid version or the pattern is malformed.
+// For matching by version order rather than by spelling, use [ParseConstraint].
+func MatchGlob(pattern, v string) bool {
+	if !IsValid(v) {
+		return false
+	}
+	ok, err := path.Match(pattern, v)
+	return err == nil && ok
+}
@@ -0,0 +1,36 @@
+package gover
+
+import "testing"
+
+var matchGlobTests = []struct {
+	pattern string
+	v       string
+	out     bool
+}{
+	{"go1.2*", "go1.2", true},
+	{"go1.2*", "go1.21", true},
+	{"go1.2*", "go1.21.3", true},
+	{"go1.2*", "go1.21rc1", true},
+	{"go1.2*", "go1.19", false},
+	{"go1.*.3", "go1.21.3", true},
+	{"go1.*.3", "go1.21.13", false},
+	{"go1.*.3", "go1.21", false},
+	{"go1.21.*", "go1.21.0", true},
+	{"go1.21.*", "go1.21", false},
+	{"go1.21.*", "go1.21rc1", false},
+	{"go1.2?.0", "go1.22.0", true},
+	{"go1.2[12].*", "go1.22.4", true},
+	{"go1.2[12].*", "go1.23.4", false},
+	{"*", "go1.21.0", true},
+	{"*", "go1.021", false},
+	{"go1.2[", "go1.2", false},
+	{"go1.21", "go1.21", true},
+}
+
+func TestMatchGlob(t *testing.T) {
+	for _, tt := range matchGlobTests {
+		if out := MatchGlob(tt.pattern, tt.v); out != tt.out {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.v, out, tt.out)
+		}
+	}
+}
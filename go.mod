@@ -1,3 +1,5 @@
 module github.com/Open-Source-CQUT/gover
 
-go 1.22.5
+go 1.23
+
+require golang.org/x/mod v0.22.0
@@ -45,12 +45,150 @@ func Lang(x string) string {
 	}
 }
 
+// Parse parses the version x, which must begin with a "go" prefix.
+// If x is not a valid version, Parse returns a [*ParseError].
 func Parse(x string) (Version, error) {
-	parsedV := parse(stripGo(x))
-	if (parsedV == Version{}) {
-		return Version{}, fmt.Errorf("invalid version %s", x)
+	v, _, err := parseFull(x)
+	if err != nil {
+		return Version{}, err
 	}
-	return parsedV, nil
+	return v, nil
+}
+
+// parseFull parses the version x, which must begin with a "go" prefix,
+// returning the version and the part of x that was parsed,
+// without any "-bigcorp" suffix.
+func parseFull(x string) (Version, string, *ParseError) {
+	s := stripGo(x)
+	if s == "" {
+		if !strings.HasPrefix(x, "go") {
+			return Version{}, "", &ParseError{Input: x, Offset: 0, Reason: ReasonBadPrefix}
+		}
+		return Version{}, "", &ParseError{Input: x, Offset: 2, Reason: ReasonMissingNumber}
+	}
+	v, rest, reason := parseDetail(s)
+	if reason != 0 {
+		return Version{}, "", &ParseError{Input: x, Offset: 2 + len(s) - len(rest), Reason: reason}
+	}
+	_, v.Suffix, _ = strings.Cut(x, "-")
+	return v, s, nil
+}
+
+// A ParseError describes a version that could not be parsed.
+type ParseError struct {
+	Input  string      // the version being parsed
+	Offset int         // byte offset in Input where parsing failed
+	Reason ParseReason // why parsing failed
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid version %s: %s at offset %d", e.Input, e.Reason, e.Offset)
+}
+
+// A ParseReason is a reason that a version could not be parsed.
+type ParseReason int
+
+const (
+	ReasonBadPrefix      ParseReason = 1 + iota // version does not begin with "go"
+	ReasonMissingNumber                         // expected decimal number is missing
+	ReasonLeadingZero                           // decimal number has an unnecessary leading zero
+	ReasonBadPrerelease                         // prerelease kind is not a lower-case word
+	ReasonTrailingText                          // unexpected text follows a complete version
+	ReasonZeroPrerelease                        // prerelease number is zero (see ParseStrict)
+)
+
+var reasonText = [...]string{
+	ReasonBadPrefix:      "missing go prefix",
+	ReasonMissingNumber:  "missing number",
+	ReasonLeadingZero:    "leading zero",
+	ReasonBadPrerelease:  "bad prerelease",
+	ReasonTrailingText:   "unexpected text",
+	ReasonZeroPrerelease: "zero prerelease number",
+}
+
+func (r ParseReason) String() string {
+	if 0 < r && int(r) < len(reasonText) {
+		return reasonText[r]
+	}
+	return fmt.Sprintf("ParseReason(%d)", int(r))
+}
+
+// MustParse is like Parse but panics if x is not a valid version.
+// It simplifies safe initialization of global variables holding
+// known versions, such as
+//
+//	var minimum = gover.MustParse("go1.21.0")
+func MustParse(x string) Version {
+	v, err := Parse(x)
+	if err != nil {
+		panic("gover: MustParse: " + err.Error())
+	}
+	return v
+}
+
+// ParseStrict is like Parse but also rejects versions that, while accepted
+// by Parse and IsValid, never name a real release.
+// Currently that is versions with a zero prerelease number, such as "go1.22rc0":
+// prerelease numbers start at 1.
+func ParseStrict(x string) (Version, error) {
+	v, s, err := parseFull(x)
+	if err != nil {
+		return Version{}, err
+	}
+	if v.Pre == "0" {
+		return Version{}, &ParseError{Input: x, Offset: 2 + len(s) - len(v.Pre), Reason: ReasonZeroPrerelease}
+	}
+	return v, nil
+}
+
+// ParseLenient is like Parse but also accepts common alternate spellings
+// of Go versions found in configuration files, CI settings, and tags:
+// surrounding white space, a missing "go" prefix, a "v" prefix in place of "go",
+// and a "Go" or "GO" prefix, optionally separated from the number by spaces.
+// For example, "1.21.3", "v1.21.3", "Go 1.21.3", and " go1.21.3\n"
+// all parse the same as "go1.21.3".
+// As with Parse, errors are reported as a [*ParseError], with the offset
+// counted in x.
+func ParseLenient(x string) (Version, error) {
+	s := strings.TrimLeft(x, " \t\r\n")
+	skipped := len(x) - len(s)
+	s = strings.TrimRight(s, " \t\r\n")
+	switch {
+	case len(s) >= 2 && strings.EqualFold(s[:2], "go"):
+		rest := strings.TrimLeft(s[2:], " \t")
+		skipped += len(s) - len(rest)
+		s = rest
+	case strings.HasPrefix(s, "v"), strings.HasPrefix(s, "V"):
+		skipped++
+		s = s[1:]
+	}
+	v, _, err := parseFull("go" + s)
+	if err != nil {
+		err.Input = x
+		err.Offset += skipped - len("go")
+		return Version{}, err
+	}
+	return v, nil
+}
+
+// Normalize returns the canonical Go toolchain name for the version x,
+// which may use any of the spellings accepted by ParseLenient.
+// Versions that compare equal and have the same suffix normalize to the same
+// name, making the result suitable for deduplicating versions from configuration.
+// For example:
+//
+//	Normalize("1.21") = "go1.21"
+//	Normalize("v1.21.3") = "go1.21.3"
+//	Normalize("go1.20.0") = "go1.20"
+//	Normalize("go1.021") = error
+//
+// See [Version.Canonical] for the details of the canonical name.
+func Normalize(x string) (string, error) {
+	v, err := ParseLenient(x)
+	if err != nil {
+		return "", err
+	}
+	return v.Canonical(), nil
 }
 
 // Compare returns -1, 0, or +1 depending on whether
@@ -87,12 +225,60 @@ func Compare(x, y string) int {
 	return compare(stripGo(x), stripGo(y))
 }
 
+// CompareDetail is like Compare but breaks ties between versions that differ
+// only in their suffixes, such as "go1.21.0-corp1" and "go1.21.0-corp2",
+// by comparing the suffixes lexically. A version with no suffix compares
+// less than the same version with a suffix.
+// For example:
+//
+//	CompareDetail("go1.21.0-corp1", "go1.21.0-corp2") = -1
+//	CompareDetail("go1.21.0", "go1.21.0-corp1") = -1
+//	CompareDetail("go1.21.1", "go1.21.0-corp1") = 1
+func CompareDetail(x, y string) int {
+	return CompareDetailFunc(x, y, strings.Compare)
+}
+
+// CompareDetailFunc is like CompareDetail but compares suffixes using cmpSuffix,
+// which is passed the suffixes without their leading "-" and must return
+// a negative number, zero, or a positive number depending on whether
+// its first argument orders before, the same as, or after the second.
+func CompareDetailFunc(x, y string, cmpSuffix func(a, b string) int) int {
+	vx, _ := Parse(x)
+	vy, _ := Parse(y)
+	if c := vx.Compare(vy); c != 0 {
+		return c
+	}
+	return cmpSuffix(vx.Suffix, vy.Suffix)
+}
+
+// CompareMixed is like Compare but accepts versions with or without
+// the "go" prefix, so that versions from sources using different
+// conventions can be compared directly.
+// For example:
+//
+//	CompareMixed("go1.21", "1.22") = -1
+//	CompareMixed("1.21.0", "go1.21.0") = 0
+//
+// As with Compare, invalid versions compare less than valid versions
+// and equal to each other.
+func CompareMixed(x, y string) int {
+	return Compare(addGo(x), addGo(y))
+}
+
+// addGo adds the "go" prefix to x if it looks like a version without one.
+func addGo(x string) string {
+	if x != "" && '0' <= x[0] && x[0] <= '9' {
+		return "go" + x
+	}
+	return x
+}
+
 // IsValid reports whether the version x is valid.
 func IsValid(x string) bool {
 	return isValid(stripGo(x))
 }
 
-// A Version is a parsed Go version: major[.Minor[.Patch]][kind[pre]]
+// A Version is a parsed Go version: major[.Minor[.Patch]][kind[pre]][-suffix]
 // The numbers are the original decimal strings to avoid integer overflows
 // and since there is very little actual math. (Probably overflow doesn't matter in practice,
 // but at the time this code was written, there was an existing test that used
@@ -104,10 +290,113 @@ type Version struct {
 	Patch string // decimal or ""
 	Kind  string // "", "alpha", "beta", "rc"
 	Pre   string // decimal or ""
+
+	// Suffix is the text after the first "-" in a toolchain name
+	// such as "go1.23.4-bigcorp", without the "-", or "" if there is none.
+	// Comparisons ignore the suffix.
+	Suffix string
+
+	// Devel reports whether the version is a development version of Go,
+	// as returned by ParseRuntime for "devel go1.23-2b1f1e8 ..." strings.
+	// Commit is the abbreviated commit hash of a development version.
+	// Major, Minor, and the other components then hold the language version
+	// under development. Comparisons ignore Devel and Commit.
+	Devel  bool
+	Commit string
+
+	// Experiment is the comma-separated list of GOEXPERIMENT settings
+	// that a toolchain reports after its version, as in "go1.21.0 X:loopvar,rangefunc",
+	// or "" if there are none. It is a string rather than a []string
+	// because a slice field would make Version incomparable: == and checks
+	// against the zero Version{} would no longer compile, and Version could
+	// not be used as a map key. Use [Version.Experiments] to split it.
+	// Comparisons ignore Experiment.
+	Experiment string
 }
 
+// String returns the version in Go toolchain name syntax,
+// including only the components that are set, followed by any suffix.
+// Development versions format as "devel go1.23-2b1f1e8", and any
+// experiments are appended as in "go1.21.0 X:loopvar", the forms
+// accepted by ParseRuntime.
+// Components that Parse fills in by default are included,
+// so Parse("go1.20") formats as "go1.20.0"; use [Version.Canonical]
+// for the conventional name.
+// The zero Version formats as the empty string.
 func (v Version) String() string {
-	return fmt.Sprintf("go%s.%s.%s%s%s", v.Major, v.Minor, v.Patch, v.Kind, v.Pre)
+	if v == (Version{}) {
+		return ""
+	}
+	s := "go" + v.Major
+	if v.Minor != "" {
+		s += "." + v.Minor
+		if v.Patch != "" {
+			s += "." + v.Patch
+		}
+	}
+	s += v.Kind + v.Pre
+	if v.Suffix != "" {
+		s += "-" + v.Suffix
+	}
+	if v.Devel {
+		s = "devel " + s
+		if v.Commit != "" {
+			s += "-" + v.Commit
+		}
+	}
+	if v.Experiment != "" {
+		s += " X:" + v.Experiment
+	}
+	return s
+}
+
+// Experiments returns the GOEXPERIMENT settings recorded in v.Experiment,
+// such as ["loopvar", "rangefunc"], or nil if there are none.
+func (v Version) Experiments() []string {
+	if v.Experiment == "" {
+		return nil
+	}
+	return strings.Split(v.Experiment, ",")
+}
+
+// Canonical returns the conventional Go toolchain name for v,
+// omitting default components that the Go project leaves out of its
+// release names: a zero patch number before Go 1.21, and a zero minor
+// number of Go 1.
+// For example:
+//
+//	MustParse("go1.20.0").Canonical() = "go1.20"
+//	MustParse("go1.21.0").Canonical() = "go1.21.0"
+//	MustParse("go1.0.0").Canonical() = "go1"
+//	MustParse("go1.9.0rc1").Canonical() = "go1.9.0rc1"
+//
+// Any suffix is kept: MustParse("go1.20.0-bigcorp").Canonical() = "go1.20-bigcorp".
+// The zero Version has the empty string as its canonical form.
+func (v Version) Canonical() string {
+	if v.Patch == "0" && v.Kind == "" && !UsesExplicitPatchZero(v.Minor) {
+		v.Patch = ""
+		if v.Minor == "0" && v.Major == "1" {
+			v.Minor = ""
+		}
+	}
+	return v.String()
+}
+
+// Lang returns the Go language version of v, with the patch and prerelease
+// components removed, as parsed from the version that the top-level Lang
+// would return. For example, MustParse("go1.21.2").Lang() is go1.21.
+// Before Go 1.21, language versions have a zero patch component,
+// so MustParse("go1.20rc1").Lang() is go1.20.0, the same as MustParse("go1.20").
+// The language version of the zero Version is the zero Version.
+func (v Version) Lang() Version {
+	if v == (Version{}) {
+		return Version{}
+	}
+	w := Version{Major: v.Major, Minor: v.Minor}
+	if !UsesExplicitPatchZero(w.Minor) {
+		w.Patch = "0"
+	}
+	return w
 }
 
 // Compare returns -1, 0, or +1 depending on whether
@@ -116,43 +405,204 @@ func (v Version) String() string {
 // Malformed versions compare less than well-formed versions and equal to each other.
 // The language version "1.21" compares less than the release candidate and eventual releases "1.21rc1" and "1.21.0".
 func compare(x, y string) int {
-	vx := parse(x)
-	vy := parse(y)
+	return parse(x).Compare(parse(y))
+}
 
-	if c := CmpInt(vx.Major, vy.Major); c != 0 {
+// Compare returns -1, 0, or +1 depending on whether
+// v < w, v == w, or v > w, interpreted as toolchain versions.
+// It orders parsed versions exactly as the top-level Compare orders
+// the strings they were parsed from, without parsing them again.
+// The zero Version, which Parse never returns, compares less than
+// all other versions.
+func (v Version) Compare(w Version) int {
+	if c := CmpInt(v.Major, w.Major); c != 0 {
 		return c
 	}
-	if c := CmpInt(vx.Minor, vy.Minor); c != 0 {
+	if c := CmpInt(v.Minor, w.Minor); c != 0 {
 		return c
 	}
-	if c := CmpInt(vx.Patch, vy.Patch); c != 0 {
+	if c := CmpInt(v.Patch, w.Patch); c != 0 {
 		return c
 	}
-	if c := cmp.Compare(vx.Kind, vy.Kind); c != 0 { // "" < alpha < beta < rc
+	if c := cmp.Compare(v.Kind, w.Kind); c != 0 { // "" < alpha < beta < rc
 		// for patch release, alpha < beta < rc < ""
-		if vx.Patch != "" {
-			if vx.Kind == "" {
+		if v.Patch != "" {
+			if v.Kind == "" {
 				c = 1
-			} else if vy.Kind == "" {
+			} else if w.Kind == "" {
 				c = -1
 			}
 		}
 		return c
 	}
-	if c := CmpInt(vx.Pre, vy.Pre); c != 0 {
+	if c := CmpInt(v.Pre, w.Pre); c != 0 {
 		return c
 	}
 	return 0
 }
 
-// Max returns the maximum of x and y interpreted as toolchain versions,
+// CompareDetail is like Compare but breaks ties between versions that
+// differ only in their suffixes by comparing the suffixes lexically;
+// see the top-level CompareDetail.
+func (v Version) CompareDetail(w Version) int {
+	if c := v.Compare(w); c != 0 {
+		return c
+	}
+	return strings.Compare(v.Suffix, w.Suffix)
+}
+
+// Less reports whether v < w, interpreted as toolchain versions.
+func (v Version) Less(w Version) bool {
+	return v.Compare(w) < 0
+}
+
+// Equal reports whether v == w, interpreted as toolchain versions.
+func (v Version) Equal(w Version) bool {
+	return v.Compare(w) == 0
+}
+
+// Max returns the maximum of vs interpreted as toolchain versions,
 // compared using Compare.
-// If x and y compare equal, Max returns x.
-func Max(x, y string) string {
-	if Compare(x, y) < 0 {
-		return y
+// If several versions compare equal to the maximum, Max returns the first.
+// If vs is empty, Max returns the empty string.
+func Max(vs ...string) string {
+	var max string
+	for i, v := range vs {
+		if i == 0 || Compare(v, max) > 0 {
+			max = v
+		}
 	}
-	return x
+	return max
+}
+
+// Min returns the minimum of vs interpreted as toolchain versions,
+// compared using Compare.
+// If several versions compare equal to the minimum, Min returns the first.
+// If vs is empty, Min returns the empty string.
+func Min(vs ...string) string {
+	var min string
+	for i, v := range vs {
+		if i == 0 || Compare(v, min) < 0 {
+			min = v
+		}
+	}
+	return min
+}
+
+// MaxVersion returns the maximum of vs, compared using [Version.Compare].
+// If several versions compare equal to the maximum, MaxVersion returns the first.
+// If vs is empty, MaxVersion returns the zero Version.
+func MaxVersion(vs ...Version) Version {
+	var max Version
+	for i, v := range vs {
+		if i == 0 || v.Compare(max) > 0 {
+			max = v
+		}
+	}
+	return max
+}
+
+// MinVersion returns the minimum of vs, compared using [Version.Compare].
+// If several versions compare equal to the minimum, MinVersion returns the first.
+// If vs is empty, MinVersion returns the zero Version.
+func MinVersion(vs ...Version) Version {
+	var min Version
+	for i, v := range vs {
+		if i == 0 || v.Compare(min) < 0 {
+			min = v
+		}
+	}
+	return min
+}
+
+// Prev returns the release preceding x.
+// For a patch release, Prev returns the previous patch release of the same
+// Go language version, keeping in mind that before Go 1.21 the first release
+// of a series was named "go1.N", not "go1.N.0".
+// For the first release of a series, a language version, or a prerelease
+// of the first release, Prev returns the previous language version.
+// For example:
+//
+//	Prev("go1.21.3") = "go1.21.2"
+//	Prev("go1.21.1") = "go1.21.0"
+//	Prev("go1.20.1") = "go1.20"
+//	Prev("go1.22.0") = "go1.21"
+//	Prev("go1.22rc1") = "go1.21"
+//	Prev("go1.21") = "go1.20"
+//	Prev("go1.9.2rc2") = "go1.9.1"
+//	Prev("go1.1") = "go1"
+//
+// If x is invalid or has no predecessor, such as "go1", Prev returns the empty string.
+func Prev(x string) string {
+	v := parse(stripGo(x))
+	if v == (Version{}) {
+		return ""
+	}
+	if v.Patch != "" && v.Patch != "0" {
+		w := Version{Major: v.Major, Minor: v.Minor, Patch: DecInt(v.Patch)}
+		return w.Canonical()
+	}
+	if v.Minor == "0" {
+		return ""
+	}
+	return Version{Major: v.Major, Minor: DecInt(v.Minor)}.Lang().Canonical()
+}
+
+// NextPatch returns the first release after x in the same Go language version.
+// For a release, that is the next patch release; for a prerelease or
+// a language version, it is the release that follows it.
+// For example:
+//
+//	NextPatch("go1.21.3") = "go1.21.4"
+//	NextPatch("go1.20") = "go1.20.1"
+//	NextPatch("go1.21") = "go1.21.0"
+//	NextPatch("go1.21rc2") = "go1.21.0"
+//	NextPatch("go1.20rc2") = "go1.20"
+//	NextPatch("go1.9.2rc2") = "go1.9.2"
+//
+// If x is invalid, NextPatch returns the empty string.
+func NextPatch(x string) string {
+	v := parse(stripGo(x))
+	if v == (Version{}) {
+		return ""
+	}
+	w := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	switch {
+	case v.Patch == "":
+		w.Patch = "0" // language version or prerelease of the first release
+	case v.Kind == "":
+		w.Patch = IncInt(v.Patch)
+	}
+	return w.Canonical()
+}
+
+// NextMinor returns the first release of the Go language version following
+// that of x, which is named "go1.N.0" starting with Go 1.21 and "go1.N" before.
+// For example:
+//
+//	NextMinor("go1.21.3") = "go1.22.0"
+//	NextMinor("go1.22rc1") = "go1.23.0"
+//	NextMinor("go1.20.5") = "go1.21.0"
+//	NextMinor("go1.18") = "go1.19"
+//
+// If x is invalid, NextMinor returns the empty string.
+func NextMinor(x string) string {
+	v := parse(stripGo(x))
+	if v == (Version{}) {
+		return ""
+	}
+	return Version{Major: v.Major, Minor: IncInt(v.Minor), Patch: "0"}.Canonical()
+}
+
+// NextLang returns the language version following that of x.
+// For example, NextLang("go1.21.3") = "go1.22".
+// If x is invalid, NextLang returns the empty string.
+func NextLang(x string) string {
+	v := parse(stripGo(x))
+	if v == (Version{}) {
+		return ""
+	}
+	return Version{Major: v.Major, Minor: IncInt(v.Minor)}.Lang().Canonical()
 }
 
 // isLang reports whether v denotes the overall Go language version
@@ -165,10 +615,65 @@ func Max(x, y string) string {
 // meaning that Go 1.21rc1 and Go 1.21.0 will both handle go.mod files that
 // say "go 1.21", but Go 1.21rc1 will not handle files that say "go 1.21.0".
 func isLang(x string) bool {
-	v := parse(x)
+	return parse(x).IsLangVersion()
+}
+
+// IsLangVersion reports whether v denotes an overall Go language version,
+// such as "go1.21", rather than a specific release or prerelease.
+// Before Go 1.21, the language version "go1.N" also named the series' first
+// release, so IsLangVersion reports false for those versions;
+// see [Version.IsRelease].
+func (v Version) IsLangVersion() bool {
 	return v != Version{} && v.Patch == "" && v.Kind == "" && v.Pre == ""
 }
 
+// IsPrerelease reports whether v denotes a prerelease (alpha, beta, or release candidate),
+// such as "go1.21rc2" or "go1.9.2rc2".
+func (v Version) IsPrerelease() bool {
+	return v != Version{} && v.Kind != ""
+}
+
+// IsRelease reports whether v denotes a specific release, such as "go1.21.0"
+// or "go1.20", and neither a prerelease nor a language version.
+func (v Version) IsRelease() bool {
+	return v != Version{} && v.Kind == "" && v.Patch != ""
+}
+
+// IsLang reports whether x denotes an overall Go language version;
+// see [Version.IsLangVersion].
+// For example:
+//
+//	IsLang("go1.21") = true
+//	IsLang("go1.21.0") = false
+//	IsLang("go1.21rc1") = false
+//	IsLang("go1.20") = false
+func IsLang(x string) bool {
+	return isLang(stripGo(x))
+}
+
+// IsPrerelease reports whether x denotes a prerelease;
+// see [Version.IsPrerelease].
+// For example:
+//
+//	IsPrerelease("go1.21rc1") = true
+//	IsPrerelease("go1.9.2beta1") = true
+//	IsPrerelease("go1.21.0") = false
+func IsPrerelease(x string) bool {
+	return parse(stripGo(x)).IsPrerelease()
+}
+
+// IsRelease reports whether x denotes a specific release;
+// see [Version.IsRelease].
+// For example:
+//
+//	IsRelease("go1.21.0") = true
+//	IsRelease("go1.20") = true
+//	IsRelease("go1.21") = false
+//	IsRelease("go1.21rc1") = false
+func IsRelease(x string) bool {
+	return parse(stripGo(x)).IsRelease()
+}
+
 // lang returns the Go language version. For example, Lang("1.2.3") == "1.2".
 func lang(x string) string {
 	v := parse(x)
@@ -186,86 +691,112 @@ func isValid(x string) bool {
 // parse parses the Go version string x into a version.
 // It returns the zero version if x is malformed.
 func parse(x string) Version {
-	var v Version
+	v, _, _ := parseDetail(x)
+	return v
+}
 
+// parseDetail is like parse but, if x is malformed,
+// also returns the unparsed remainder of x where parsing failed
+// and the reason it failed.
+func parseDetail(x string) (v Version, rest string, reason ParseReason) {
 	// Parse major version.
 	var ok bool
-	v.Major, x, ok = cutInt(x)
+	v.Major, rest, ok = cutInt(x)
 	if !ok {
-		return Version{}
+		return Version{}, x, cutIntReason(x)
 	}
-	if x == "" {
+	if rest == "" {
 		// Interpret "1" as "1.0.0".
 		v.Minor = "0"
 		v.Patch = "0"
-		return v
+		return v, "", 0
 	}
 
 	// Parse . before minor version.
-	if x[0] != '.' {
-		return Version{}
+	if rest[0] != '.' {
+		return Version{}, rest, ReasonTrailingText
 	}
 
 	// Parse minor version.
-	v.Minor, x, ok = cutInt(x[1:])
+	x = rest[1:]
+	v.Minor, rest, ok = cutInt(x)
 	if !ok {
-		return Version{}
+		return Version{}, x, cutIntReason(x)
 	}
-	if x == "" {
+	if rest == "" {
 		// Patch missing is same as "0" for older versions.
 		// Starting in Go 1.21, patch missing is different from explicit .0.
-		if CmpInt(v.Minor, "21") < 0 {
+		if !UsesExplicitPatchZero(v.Minor) {
 			v.Patch = "0"
 		}
-		return v
+		return v, "", 0
 	}
 
 	// Parse patch if present.
-	if x[0] == '.' {
-		v.Patch, x, ok = cutInt(x[1:])
+	if rest[0] == '.' {
+		x = rest[1:]
+		v.Patch, rest, ok = cutInt(x)
 		if !ok {
-			return Version{}
+			return Version{}, x, cutIntReason(x)
 		}
 
 		// If there has prerelease for patch releases.
-		if x != "" {
-			v.Kind, v.Pre, ok = parsePreRelease(x)
-			if !ok {
-				return Version{}
+		if rest != "" {
+			v.Kind, v.Pre, rest, reason = parsePreRelease(rest)
+			if reason != 0 {
+				return Version{}, rest, reason
 			}
 		}
 
-		return v
+		return v, "", 0
 	}
 
 	// Parse prerelease.
-	v.Kind, v.Pre, ok = parsePreRelease(x)
-	if !ok {
-		return Version{}
+	v.Kind, v.Pre, rest, reason = parsePreRelease(rest)
+	if reason != 0 {
+		return Version{}, rest, reason
 	}
-	return v
+	return v, "", 0
+}
+
+// UsesExplicitPatchZero reports whether the Go 1.N release series with
+// minor version N = langMinor names its first release "1.N.0" rather than "1.N".
+// Starting with Go 1.21, "1.N" denotes the language version and is distinct
+// from the release "1.N.0"; for older series, "1.N" and "1.N.0" are the same.
+// For example:
+//
+//	UsesExplicitPatchZero("20") = false
+//	UsesExplicitPatchZero("21") = true
+func UsesExplicitPatchZero(langMinor string) bool {
+	return CmpInt(langMinor, "21") >= 0
 }
 
-func parsePreRelease(x string) (kind, pre string, ok bool) {
+// parsePreRelease parses a prerelease suffix such as "rc1".
+// If x is malformed, it returns the remainder of x where parsing failed
+// and the reason it failed.
+func parsePreRelease(x string) (kind, pre, rest string, reason ParseReason) {
 	i := 0
 	for i < len(x) && (x[i] < '0' || '9' < x[i]) {
 		if x[i] < 'a' || 'z' < x[i] {
-			return "", "", false
+			return "", "", x[i:], ReasonBadPrerelease
 		}
 		i++
 	}
 	if i == 0 {
-		return "", "", false
+		return "", "", x, ReasonBadPrerelease
 	}
 	kind, x = x[:i], x[i:]
 	if x == "" {
-		return kind, "", true
+		return kind, "", "", 0
 	}
-	pre, x, ok = cutInt(x)
-	if !ok || x != "" {
-		return "", "", false
+	pre, rest, ok := cutInt(x)
+	if !ok {
+		return "", "", x, cutIntReason(x)
+	}
+	if rest != "" {
+		return "", "", rest, ReasonTrailingText
 	}
-	return kind, pre, true
+	return kind, pre, "", 0
 }
 
 // cutInt scans the leading decimal number at the start of x to an integer
@@ -281,6 +812,14 @@ func cutInt(x string) (n, rest string, ok bool) {
 	return x[:i], x[i:], true
 }
 
+// cutIntReason returns the reason that cutInt(x) failed.
+func cutIntReason(x string) ParseReason {
+	if len(x) > 1 && x[0] == '0' && '0' <= x[1] && x[1] <= '9' {
+		return ReasonLeadingZero
+	}
+	return ReasonMissingNumber
+}
+
 // CmpInt returns cmp.Compare(x, y) interpreting x and y as decimal numbers.
 // (Copied from golang.org/x/mod/semver's compareInt.)
 func CmpInt(x, y string) int {
@@ -321,3 +860,45 @@ func DecInt(decimal string) string {
 	}
 	return string(digits)
 }
+
+// IncInt returns the decimal string incremented by 1.
+func IncInt(decimal string) string {
+	// Scan right to left turning 9s to 0s until you find a digit to increment.
+	digits := []byte(decimal)
+	i := len(digits) - 1
+	for ; i >= 0 && digits[i] == '9'; i-- {
+		digits[i] = '0'
+	}
+	if i < 0 {
+		// decimal is all nines
+		return "1" + string(digits)
+	}
+	digits[i]++
+	return string(digits)
+}
+
+// NextLangExcluding returns the first language version after the language
+// version of x, in increasing minor version order, that does not compare
+// equal to any of the versions in blocked.
+// For example, NextLangExcluding("go1.20.3", []string{"go1.21"}) = "go1.22".
+// If x is invalid, or no acceptable version is found within len(blocked)+1
+// steps, NextLangExcluding returns "", false.
+func NextLangExcluding(x string, blocked []string) (string, bool) {
+	v := parse(stripGo(x))
+	if v == (Version{}) {
+		return "", false
+	}
+	minor := v.Minor
+Search:
+	for range len(blocked) + 1 {
+		minor = IncInt(minor)
+		next := "go" + v.Major + "." + minor
+		for _, b := range blocked {
+			if Compare(next, b) == 0 {
+				continue Search
+			}
+		}
+		return next, true
+	}
+	return "", false
+}
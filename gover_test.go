@@ -6,6 +6,7 @@ package gover
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -47,6 +48,112 @@ var compareTests = []testCase2[string, string, int]{
 	{"go1.99999999999999998", "go1.99999999999999999", -1},
 }
 
+func TestVersionCompare(t *testing.T) {
+	for _, tt := range compareTests {
+		v, w := parse(stripGo(tt.in1)), parse(stripGo(tt.in2))
+		if out := v.Compare(w); out != tt.out {
+			t.Errorf("Version(%q).Compare(%q) = %d, want %d", tt.in1, tt.in2, out, tt.out)
+		}
+		if out := v.Less(w); out != (tt.out < 0) {
+			t.Errorf("Version(%q).Less(%q) = %v, want %v", tt.in1, tt.in2, out, tt.out < 0)
+		}
+		if out := v.Equal(w); out != (tt.out == 0) {
+			t.Errorf("Version(%q).Equal(%q) = %v, want %v", tt.in1, tt.in2, out, tt.out == 0)
+		}
+		if out := w.Compare(v); out != -tt.out {
+			t.Errorf("Version(%q).Compare(%q) = %d, want %d", tt.in2, tt.in1, out, -tt.out)
+		}
+	}
+}
+
+func TestCompareDetail(t *testing.T) { test2(t, compareDetailTests, "CompareDetail", CompareDetail) }
+
+var compareDetailTests = []testCase2[string, string, int]{
+	{"go1.21.0-corp1", "go1.21.0-corp2", -1},
+	{"go1.21.0-corp2", "go1.21.0-corp1", 1},
+	{"go1.21.0-corp1", "go1.21.0-corp1", 0},
+	{"go1.21.0", "go1.21.0-corp1", -1},
+	{"go1.21.1", "go1.21.0-corp1", 1},
+	{"go1.20-corp", "go1.20.0-corp", 0},
+	{"go1.21rc1-b", "go1.21.0-a", -1},
+	{"bad-a", "bad-b", 0},
+	{"go1.21", "go1.21", 0},
+}
+
+func TestCompareDetailFunc(t *testing.T) {
+	// Order numbered builds numerically, so that corp10 follows corp9.
+	build := func(a, b string) int {
+		return CmpInt(strings.TrimPrefix(a, "corp"), strings.TrimPrefix(b, "corp"))
+	}
+	for _, tt := range []testCase2[string, string, int]{
+		{"go1.21.0-corp9", "go1.21.0-corp10", -1},
+		{"go1.21.0-corp10", "go1.21.0-corp9", 1},
+		{"go1.21.1-corp1", "go1.21.0-corp10", 1},
+	} {
+		if out := CompareDetailFunc(tt.in1, tt.in2, build); out != tt.out {
+			t.Errorf("CompareDetailFunc(%q, %q) = %d, want %d", tt.in1, tt.in2, out, tt.out)
+		}
+	}
+	for _, tt := range compareDetailTests {
+		v, _ := Parse(tt.in1)
+		w, _ := Parse(tt.in2)
+		if out := v.CompareDetail(w); out != tt.out {
+			t.Errorf("Version(%q).CompareDetail(%q) = %d, want %d", tt.in1, tt.in2, out, tt.out)
+		}
+	}
+}
+
+func TestCompareMixed(t *testing.T) { test2(t, compareMixedTests, "CompareMixed", CompareMixed) }
+
+var compareMixedTests = []testCase2[string, string, int]{
+	{"go1.21", "1.22", -1},
+	{"1.22", "go1.21", 1},
+	{"1.21.0", "go1.21.0", 0},
+	{"1.21", "1.21rc1", -1},
+	{"go1.20", "1.20.0", 0},
+	{"1.22.0-bigcorp", "go1.22.1", -1},
+	{"bad", "1.21", -1},
+	{"1.21", "gogo1.21", 1},
+	{"go", "1.x", 0},
+	{"", "", 0},
+}
+
+var maxMinTests = []struct {
+	in  []string
+	max string
+	min string
+}{
+	{nil, "", ""},
+	{[]string{"go1.21"}, "go1.21", "go1.21"},
+	{[]string{"go1.21", "go1.22"}, "go1.22", "go1.21"},
+	{[]string{"go1.21.0", "go1.21rc1", "go1.21"}, "go1.21.0", "go1.21"},
+	{[]string{"go1.20", "go1.20.0"}, "go1.20", "go1.20"},
+	{[]string{"go1.20.0", "go1.20"}, "go1.20.0", "go1.20.0"},
+	{[]string{"bad", "go1.19", "", "go1.5"}, "go1.19", "bad"},
+	{[]string{"go1.9.2", "go1.9.2rc2", "go1.10beta1"}, "go1.10beta1", "go1.9.2rc2"},
+}
+
+func TestMaxMin(t *testing.T) {
+	for _, tt := range maxMinTests {
+		if out := Max(tt.in...); out != tt.max {
+			t.Errorf("Max(%q) = %q, want %q", tt.in, out, tt.max)
+		}
+		if out := Min(tt.in...); out != tt.min {
+			t.Errorf("Min(%q) = %q, want %q", tt.in, out, tt.min)
+		}
+		var vs []Version
+		for _, x := range tt.in {
+			vs = append(vs, parse(stripGo(x)))
+		}
+		if out, want := MaxVersion(vs...), parse(stripGo(tt.max)); out != want {
+			t.Errorf("MaxVersion(%q) = %+v, want %+v", tt.in, out, want)
+		}
+		if out, want := MinVersion(vs...), parse(stripGo(tt.min)); out != want {
+			t.Errorf("MinVersion(%q) = %+v, want %+v", tt.in, out, want)
+		}
+	}
+}
+
 func TestLang(t *testing.T) { test1(t, langTests, "Lang", Lang) }
 
 var langTests = []testCase1[string, string]{
@@ -60,6 +167,90 @@ var langTests = []testCase1[string, string]{
 	{"go1.999testmod", "go1.999"},
 }
 
+func TestVersionLang(t *testing.T) {
+	for _, tt := range langTests {
+		v := parse(stripGo(tt.in))
+		want := parse(stripGo(tt.out))
+		if out := v.Lang(); out != want {
+			t.Errorf("Version(%q).Lang() = %+v, want %+v", tt.in, out, want)
+		}
+	}
+	for _, x := range []string{"go1.21.3", "go1.22rc1", "go1.9.2rc2", "go1.20"} {
+		v := MustParse(x)
+		if out, want := v.Lang().String(), MustParse(Lang(x)).String(); out != want {
+			t.Errorf("Parse(%q).Lang() = %s, want %s", x, out, want)
+		}
+	}
+}
+
+func TestPrev(t *testing.T) { test1(t, prevTests, "Prev", Prev) }
+
+var prevTests = []testCase1[string, string]{
+	{"", ""},
+	{"1.21.3", ""},
+	{"go1.21.3", "go1.21.2"},
+	{"go1.21.1", "go1.21.0"},
+	{"go1.21.10", "go1.21.9"},
+	{"go1.21.0", "go1.20"},
+	{"go1.21", "go1.20"},
+	{"go1.21rc2", "go1.20"},
+	{"go1.22.0", "go1.21"},
+	{"go1.22rc1", "go1.21"},
+	{"go1.20.1", "go1.20"},
+	{"go1.20", "go1.19"},
+	{"go1.9.2rc2", "go1.9.1"},
+	{"go1.9.1", "go1.9"},
+	{"go1.2.3-bigcorp", "go1.2.2"},
+	{"go1.1", "go1"},
+	{"go1", ""},
+}
+
+func TestNextPatch(t *testing.T) { test1(t, nextPatchTests, "NextPatch", NextPatch) }
+
+var nextPatchTests = []testCase1[string, string]{
+	{"", ""},
+	{"1.21.3", ""},
+	{"go1.21.3", "go1.21.4"},
+	{"go1.21.9", "go1.21.10"},
+	{"go1.21.0", "go1.21.1"},
+	{"go1.21", "go1.21.0"},
+	{"go1.21rc2", "go1.21.0"},
+	{"go1.20", "go1.20.1"},
+	{"go1.20rc2", "go1.20"},
+	{"go1.9.2rc2", "go1.9.2"},
+	{"go1.9.2", "go1.9.3"},
+	{"go1", "go1.0.1"},
+	{"go1.21.3-bigcorp", "go1.21.4"},
+}
+
+func TestNextMinor(t *testing.T) { test1(t, nextMinorTests, "NextMinor", NextMinor) }
+
+var nextMinorTests = []testCase1[string, string]{
+	{"", ""},
+	{"1.21.3", ""},
+	{"go1.21.3", "go1.22.0"},
+	{"go1.21", "go1.22.0"},
+	{"go1.22rc1", "go1.23.0"},
+	{"go1.20.5", "go1.21.0"},
+	{"go1.20rc1", "go1.21.0"},
+	{"go1.18", "go1.19"},
+	{"go1.9.2rc2", "go1.10"},
+	{"go1", "go1.1"},
+}
+
+func TestNextLang(t *testing.T) { test1(t, nextLangTests, "NextLang", NextLang) }
+
+var nextLangTests = []testCase1[string, string]{
+	{"", ""},
+	{"1.21.3", ""},
+	{"go1.21.3", "go1.22"},
+	{"go1.21", "go1.22"},
+	{"go1.22rc1", "go1.23"},
+	{"go1.19.13", "go1.20"},
+	{"go1.9.2rc2", "go1.10"},
+	{"go1", "go1.1"},
+}
+
 func TestIsValid(t *testing.T) { test1(t, isValidTests, "IsValid", IsValid) }
 
 var isValidTests = []testCase1[string, bool]{
@@ -83,6 +274,325 @@ var isValidTests = []testCase1[string, bool]{
 	{"go1", true},
 }
 
+func TestIsLang(t *testing.T) { test1(t, isLangTests, "IsLang", IsLang) }
+
+var isLangTests = []testCase1[string, bool]{
+	{"", false},
+	{"bad", false},
+	{"1.21", false},
+	{"go1.21", true},
+	{"go1.22", true},
+	{"go1.21.0", false},
+	{"go1.21rc1", false},
+	{"go1.20", false},
+	{"go1.20.0", false},
+	{"go1", false},
+}
+
+func TestIsPrerelease(t *testing.T) { test1(t, isPrereleaseTests, "IsPrerelease", IsPrerelease) }
+
+var isPrereleaseTests = []testCase1[string, bool]{
+	{"", false},
+	{"1.21rc1", false},
+	{"go1.21rc1", true},
+	{"go1.21beta2", true},
+	{"go1.19alpha1", true},
+	{"go1.9.2rc2", true},
+	{"go1.21", false},
+	{"go1.21.0", false},
+	{"go1.20", false},
+}
+
+func TestIsRelease(t *testing.T) { test1(t, isReleaseTests, "IsRelease", IsRelease) }
+
+var isReleaseTests = []testCase1[string, bool]{
+	{"", false},
+	{"1.21.0", false},
+	{"go1.21.0", true},
+	{"go1.21.3-bigcorp", true},
+	{"go1.20", true},
+	{"go1.9.2", true},
+	{"go1", true},
+	{"go1.21", false},
+	{"go1.21rc1", false},
+	{"go1.9.2rc2", false},
+}
+
+func TestUsesExplicitPatchZero(t *testing.T) {
+	test1(t, usesExplicitPatchZeroTests, "UsesExplicitPatchZero", UsesExplicitPatchZero)
+}
+
+var usesExplicitPatchZeroTests = []testCase1[string, bool]{
+	{"0", false},
+	{"9", false},
+	{"20", false},
+	{"21", true},
+	{"22", true},
+	{"100", true},
+	{"99999999999999999999", true},
+}
+
+func TestIncInt(t *testing.T) { test1(t, incIntTests, "IncInt", IncInt) }
+
+var incIntTests = []testCase1[string, string]{
+	{"0", "1"},
+	{"1", "2"},
+	{"9", "10"},
+	{"20", "21"},
+	{"199", "200"},
+	{"99999999999999999999", "100000000000000000000"},
+}
+
+func TestDecInt(t *testing.T) { test1(t, decIntTests, "DecInt", DecInt) }
+
+var decIntTests = []testCase1[string, string]{
+	{"0", ""},
+	{"1", "0"},
+	{"10", "9"},
+	{"21", "20"},
+	{"200", "199"},
+	{"100000000000000000000", "99999999999999999999"},
+}
+
+func TestNextLangExcluding(t *testing.T) {
+	for _, tt := range nextLangExcludingTests {
+		out, ok := NextLangExcluding(tt.in, tt.blocked)
+		if out != tt.out || ok != tt.ok {
+			t.Errorf("NextLangExcluding(%q, %q) = %q, %v, want %q, %v", tt.in, tt.blocked, out, ok, tt.out, tt.ok)
+		}
+	}
+}
+
+var nextLangExcludingTests = []struct {
+	in      string
+	blocked []string
+	out     string
+	ok      bool
+}{
+	{"go1.20", nil, "go1.21", true},
+	{"go1.20", []string{"go1.21"}, "go1.22", true},
+	{"go1.20.5", []string{"go1.21", "go1.22"}, "go1.23", true},
+	{"go1.21rc2", []string{"go1.23"}, "go1.22", true},
+	{"go1.18", []string{"go1.19.0"}, "go1.20", true},
+	{"go1.21", []string{"go1.22.0"}, "go1.22", true},
+	{"go1.9", []string{"bad"}, "go1.10", true},
+	{"go1", nil, "go1.1", true},
+	{"bad", nil, "", false},
+}
+
+var parseErrorTests = []struct {
+	in     string
+	offset int
+	reason ParseReason
+}{
+	{"", 0, ReasonBadPrefix},
+	{"1.21", 0, ReasonBadPrefix},
+	{"g1.21", 0, ReasonBadPrefix},
+	{"go", 2, ReasonMissingNumber},
+	{"go-bigcorp", 2, ReasonMissingNumber},
+	{"go.21", 2, ReasonMissingNumber},
+	{"go1.", 4, ReasonMissingNumber},
+	{"go1.21.", 7, ReasonMissingNumber},
+	{"go01.21", 2, ReasonLeadingZero},
+	{"go1.021", 4, ReasonLeadingZero},
+	{"go1.21.03", 7, ReasonLeadingZero},
+	{"go1.21rc01", 8, ReasonLeadingZero},
+	{"go1.600+auto", 7, ReasonBadPrerelease},
+	{"go1.9.2+rc2", 7, ReasonBadPrerelease},
+	{"go1.21RC1", 6, ReasonBadPrerelease},
+	{"go1x", 3, ReasonTrailingText},
+	{"go1.21rc1x", 9, ReasonTrailingText},
+	{"go1.21rc1.2", 9, ReasonTrailingText},
+	{"go1.21.3rc1-", 0, 0},
+	{"go1.21.3-bigcorp", 0, 0},
+}
+
+func TestParseError(t *testing.T) {
+	for _, tt := range parseErrorTests {
+		_, err := Parse(tt.in)
+		if tt.reason == 0 {
+			if err != nil {
+				t.Errorf("Parse(%q): %v", tt.in, err)
+			}
+			continue
+		}
+		perr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("Parse(%q) = %v, want *ParseError", tt.in, err)
+			continue
+		}
+		if perr.Input != tt.in || perr.Offset != tt.offset || perr.Reason != tt.reason {
+			t.Errorf("Parse(%q) = %+v, want offset %d, reason %v", tt.in, *perr, tt.offset, tt.reason)
+		}
+	}
+}
+
+func TestParseSuffix(t *testing.T) {
+	for _, tt := range []struct {
+		in  string
+		out Version
+	}{
+		{"go1.21.3", Version{Major: "1", Minor: "21", Patch: "3"}},
+		{"go1.23.4-bigcorp", Version{Major: "1", Minor: "23", Patch: "4", Suffix: "bigcorp"}},
+		{"go1.22rc1-a-b", Version{Major: "1", Minor: "22", Kind: "rc", Pre: "1", Suffix: "a-b"}},
+		{"go1.21-", Version{Major: "1", Minor: "21"}},
+	} {
+		if v, err := Parse(tt.in); err != nil || v != tt.out {
+			t.Errorf("Parse(%q) = %+v, %v, want %+v", tt.in, v, err, tt.out)
+		}
+	}
+	if c := MustParse("go1.21.0-a").Compare(MustParse("go1.21.0-b")); c != 0 {
+		t.Errorf("Compare of versions differing only in suffix = %d, want 0", c)
+	}
+}
+
+var parseLenientTests = []struct {
+	in     string
+	out    string
+	offset int // of error
+}{
+	{"go1.21.3", "go1.21.3", 0},
+	{"1.21.3", "go1.21.3", 0},
+	{"v1.21.3", "go1.21.3", 0},
+	{"V1.21", "go1.21", 0},
+	{"Go 1.21.3", "go1.21.3", 0},
+	{"GO1.22rc1", "go1.22rc1", 0},
+	{"go  1.20", "go1.20.0", 0},
+	{"  go1.21.3\n", "go1.21.3", 0},
+	{"\t1.23.4-bigcorp ", "go1.23.4-bigcorp", 0},
+	{"", "", 0},
+	{"   ", "", 3},
+	{"1.021", "", 2},
+	{" v1.21.x", "", 7},
+	{"Go 1.21 rc1", "", 7},
+	{"gov1.21", "", 2},
+	{"vv1.21", "", 1},
+}
+
+func TestParseLenient(t *testing.T) {
+	for _, tt := range parseLenientTests {
+		v, err := ParseLenient(tt.in)
+		if tt.out != "" {
+			if err != nil || v.String() != tt.out {
+				t.Errorf("ParseLenient(%q) = %v, %v, want %v", tt.in, v, err, tt.out)
+			}
+			continue
+		}
+		perr, ok := err.(*ParseError)
+		if !ok || perr.Input != tt.in || perr.Offset != tt.offset {
+			t.Errorf("ParseLenient(%q) = %v, %#v, want *ParseError at offset %d", tt.in, v, err, tt.offset)
+		}
+	}
+}
+
+var normalizeTests = []struct {
+	in  string
+	out string
+	err bool
+}{
+	{"go1.21", "go1.21", false},
+	{"1.21", "go1.21", false},
+	{"v1.21.3", "go1.21.3", false},
+	{" Go 1.22rc1 ", "go1.22rc1", false},
+	{"go1.20", "go1.20", false},
+	{"go1.20.0", "go1.20", false},
+	{"1.0.0", "go1", false},
+	{"go1.21.0", "go1.21.0", false},
+	{"go1.20.0-bigcorp", "go1.20-bigcorp", false},
+	{"go1.021", "", true},
+	{"", "", true},
+	{"latest", "", true},
+}
+
+func TestNormalize(t *testing.T) {
+	for _, tt := range normalizeTests {
+		out, err := Normalize(tt.in)
+		if out != tt.out || (err != nil) != tt.err {
+			t.Errorf("Normalize(%q) = %q, %v, want %q, err=%v", tt.in, out, err, tt.out, tt.err)
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if v, want := MustParse("go1.21rc2"), (Version{Major: "1", Minor: "21", Kind: "rc", Pre: "2"}); v != want {
+		t.Errorf("MustParse(%q) = %+v, want %+v", "go1.21rc2", v, want)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("MustParse(%q) did not panic", "1.21")
+		} else if msg, want := r.(string), "gover: MustParse: invalid version 1.21: missing go prefix at offset 0"; msg != want {
+			t.Errorf("MustParse(%q) panicked with %q, want %q", "1.21", msg, want)
+		}
+	}()
+	MustParse("1.21")
+}
+
+var stringTests = []struct {
+	in        string
+	string    string
+	canonical string
+}{
+	{"go1", "go1.0.0", "go1"},
+	{"go1.0", "go1.0.0", "go1"},
+	{"go1.2", "go1.2.0", "go1.2"},
+	{"go1.2rc3", "go1.2rc3", "go1.2rc3"},
+	{"go1.2.3", "go1.2.3", "go1.2.3"},
+	{"go1.9.0rc1", "go1.9.0rc1", "go1.9.0rc1"},
+	{"go1.9.2rc2", "go1.9.2rc2", "go1.9.2rc2"},
+	{"go1.20", "go1.20.0", "go1.20"},
+	{"go1.20.0", "go1.20.0", "go1.20"},
+	{"go1.21", "go1.21", "go1.21"},
+	{"go1.21.0", "go1.21.0", "go1.21.0"},
+	{"go1.21rc2", "go1.21rc2", "go1.21rc2"},
+	{"go1.999testmod", "go1.999testmod", "go1.999testmod"},
+	{"go2", "go2.0.0", "go2.0"},
+	{"go1.21.3-bigcorp", "go1.21.3-bigcorp", "go1.21.3-bigcorp"},
+	{"go1.20.0-bigcorp", "go1.20.0-bigcorp", "go1.20-bigcorp"},
+	{"go1.22rc1-corp-build-7", "go1.22rc1-corp-build-7", "go1.22rc1-corp-build-7"},
+}
+
+func TestString(t *testing.T) {
+	for _, tt := range stringTests {
+		v := MustParse(tt.in)
+		if out := v.String(); out != tt.string {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.in, out, tt.string)
+		}
+		if out := v.Canonical(); out != tt.canonical {
+			t.Errorf("Parse(%q).Canonical() = %q, want %q", tt.in, out, tt.canonical)
+		}
+		for _, out := range []string{v.String(), v.Canonical()} {
+			if w, err := Parse(out); err != nil || w != v {
+				t.Errorf("Parse(%q) = %+v, %v, want %+v (round trip of %q)", out, w, err, v, tt.in)
+			}
+		}
+	}
+	if out := (Version{}).String(); out != "" {
+		t.Errorf("Version{}.String() = %q, want \"\"", out)
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	for _, tt := range parseStrictTests {
+		v, err := ParseStrict(tt.in)
+		if tt.err == "" && (err != nil || v != tt.out) || tt.err != "" && (err == nil || err.Error() != tt.err) {
+			t.Errorf("ParseStrict(%q) = %+v, %v, want %+v, %q", tt.in, v, err, tt.out, tt.err)
+		}
+	}
+}
+
+var parseStrictTests = []struct {
+	in  string
+	out Version
+	err string
+}{
+	{"go1.22rc1", Version{Major: "1", Minor: "22", Kind: "rc", Pre: "1"}, ""},
+	{"go1.22rc0", Version{}, "invalid version go1.22rc0: zero prerelease number at offset 8"},
+	{"go1.9.2beta0", Version{}, "invalid version go1.9.2beta0: zero prerelease number at offset 11"},
+	{"go1.22.0", Version{Major: "1", Minor: "22", Patch: "0"}, ""},
+	{"go1.22rc01", Version{}, "invalid version go1.22rc01: leading zero at offset 8"},
+	{"bad", Version{}, "invalid version bad: missing go prefix at offset 0"},
+}
+
 type testCase1[In, Out any] struct {
 	in  In
 	out Out
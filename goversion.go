@@ -0,0 +1,130 @@
+package gover
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// ParseGoVersionOutput parses the output of the "go version" command,
+// such as
+//
+//	go version go1.22.1 linux/amd64
+//
+// returning the toolchain's version and its GOOS and GOARCH.
+// The version is parsed with [ParseRuntime], so development toolchains,
+// which report versions such as
+//
+//	go version devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000 linux/amd64
+//
+// and toolchains built with GOEXPERIMENT settings are accepted.
+// Surrounding white space, such as the final newline, is ignored.
+func ParseGoVersionOutput(s string) (v Version, goos, goarch string, err error) {
+	line := strings.TrimSpace(s)
+	rest, ok := strings.CutPrefix(line, "go version ")
+	if !ok || strings.Contains(rest, "\n") {
+		return Version{}, "", "", fmt.Errorf("malformed go version output %q", s)
+	}
+	i := strings.LastIndexByte(rest, ' ')
+	if i < 0 {
+		return Version{}, "", "", fmt.Errorf("malformed go version output %q: missing GOOS/GOARCH", s)
+	}
+	goos, goarch, ok = strings.Cut(rest[i+1:], "/")
+	if !ok || goos == "" || goarch == "" || strings.Contains(goarch, "/") {
+		return Version{}, "", "", fmt.Errorf("malformed go version output %q: missing GOOS/GOARCH", s)
+	}
+	v, err = ParseRuntime(strings.TrimSpace(rest[:i]))
+	if err != nil {
+		return Version{}, "", "", fmt.Errorf("malformed go version output %q: %v", s, err)
+	}
+	return v, goos, goarch, nil
+}
+
+// A BuildReport is the report printed by "go version -m" for one binary.
+type BuildReport struct {
+	File    string           // file name of the binary
+	Version Version          // version of the toolchain that built it
+	Info    *debug.BuildInfo // module and build information
+}
+
+// ParseGoVersionM parses the output of the "go version -m" command,
+// which reports the toolchain version and the module and build
+// information of one or more binaries, as in
+//
+//	/usr/local/bin/stringer: go1.22.1
+//		path	golang.org/x/tools/cmd/stringer
+//		mod	golang.org/x/tools	v0.19.0	h1:tfGCXNR1OsFG+sVdLAitlpjAvD/I6dHDKnYrpEZUHkw=
+//		dep	golang.org/x/mod	v0.16.0	h1:QX4fJ0Rr5cPQCF7O9lh9Se4pmwfwskqZfq5moyldzic=
+//		build	-buildmode=exe
+//		build	GOOS=linux
+//
+// returning a report for each binary, in order.
+// The module and build information is parsed with [debug.ParseBuildInfo],
+// and its GoVersion field is set to the version as reported.
+func ParseGoVersionM(s string) ([]BuildReport, error) {
+	var reports []BuildReport
+	var info strings.Builder
+	flush := func() error {
+		if len(reports) == 0 {
+			return nil
+		}
+		r := &reports[len(reports)-1]
+		bi, err := debug.ParseBuildInfo(info.String())
+		if err != nil {
+			return fmt.Errorf("go version -m output for %s: %v", r.File, err)
+		}
+		bi.GoVersion = r.Info.GoVersion
+		r.Info = bi
+		info.Reset()
+		return nil
+	}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "\t") {
+			if len(reports) == 0 {
+				return nil, fmt.Errorf("malformed go version -m output: %q before first binary", line)
+			}
+			info.WriteString(line[1:])
+			info.WriteString("\n")
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		// Split at the last ": ", since Windows file names contain colons.
+		i := strings.LastIndex(line, ": ")
+		if i < 0 {
+			return nil, fmt.Errorf("malformed go version -m output: %q", line)
+		}
+		v, err := ParseRuntime(line[i+2:])
+		if err != nil {
+			return nil, fmt.Errorf("malformed go version -m output: %q: %v", line, err)
+		}
+		reports = append(reports, BuildReport{File: line[:i], Version: v, Info: &debug.BuildInfo{GoVersion: line[i+2:]}})
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// BinaryVersion returns the version of the Go toolchain that built the
+// executable in the file path, as recorded in its build information,
+// without running it or the go command.
+// The version is parsed with [ParseRuntime], so binaries built by
+// development toolchains are accepted.
+func BinaryVersion(path string) (Version, error) {
+	info, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return Version{}, err
+	}
+	v, err := ParseRuntime(info.GoVersion)
+	if err != nil {
+		return Version{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,135 @@
+package gover
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+var goVersionOutputTests = []struct {
+	in            string
+	version       string // String of parsed version, or "" for error
+	goos, goarch  string
+	devel, commit bool
+}{
+	{"go version go1.22.1 linux/amd64\n", "go1.22.1", "linux", "amd64", false, false},
+	{"go version go1.21rc2 darwin/arm64", "go1.21rc2", "darwin", "arm64", false, false},
+	{"  go version go1.20 windows/386\r\n", "go1.20.0", "windows", "386", false, false},
+	{"go version go1.21.0 X:loopvar,rangefunc linux/amd64\n", "go1.21.0 X:loopvar,rangefunc", "linux", "amd64", false, false},
+	{"go version devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000 linux/amd64\n", "devel go1.23-2b1f1e8", "linux", "amd64", true, true},
+	{"go version go1.22.1-bigcorp linux/amd64", "go1.22.1-bigcorp", "linux", "amd64", false, false},
+	{"go version go1.22.1", "", "", "", false, false},
+	{"go version go1.22.1 linux", "", "", "", false, false},
+	{"go version go1.22.1 linux/", "", "", "", false, false},
+	{"go version go1.22.1 linux/amd64/v3", "", "", "", false, false},
+	{"go version 1.22.1 linux/amd64", "", "", "", false, false},
+	{"go version devel +2b1f1e8 Thu Jan 4 20:00:00 2024 +0000 linux/amd64", "", "", "", false, false},
+	{"go1.22.1 linux/amd64", "", "", "", false, false},
+	{"go version go1.22.1 linux/amd64\ngo version go1.22.1 linux/amd64", "", "", "", false, false},
+	{"", "", "", "", false, false},
+}
+
+func TestParseGoVersionOutput(t *testing.T) {
+	for _, tt := range goVersionOutputTests {
+		v, goos, goarch, err := ParseGoVersionOutput(tt.in)
+		if tt.version == "" {
+			if err == nil {
+				t.Errorf("ParseGoVersionOutput(%q) = %v, %q, %q, nil, want error", tt.in, v, goos, goarch)
+			}
+			continue
+		}
+		if err != nil || v.String() != tt.version || goos != tt.goos || goarch != tt.goarch ||
+			v.Devel != tt.devel || (v.Commit != "") != tt.commit {
+			t.Errorf("ParseGoVersionOutput(%q) = %+v, %q, %q, %v, want %s, %q, %q", tt.in, v, goos, goarch, err, tt.version, tt.goos, tt.goarch)
+		}
+	}
+}
+
+const goVersionM = `/usr/local/bin/stringer: go1.22.1
+	path	golang.org/x/tools/cmd/stringer
+	mod	golang.org/x/tools	v0.19.0	h1:tfGCXNR1OsFG+sVdLAitlpjAvD/I6dHDKnYrpEZUHkw=
+	dep	golang.org/x/mod	v0.16.0	h1:QX4fJ0Rr5cPQCF7O9lh9Se4pmwfwskqZfq5moyldzic=
+	dep	golang.org/x/sync	v0.6.0
+	=>	../sync	(devel)	
+	build	-buildmode=exe
+	build	CGO_ENABLED=1
+	build	GOARCH=amd64
+	build	GOOS=linux
+C:\Users\gopher\go\bin\hello.exe: devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000
+	path	example.com/hello
+	mod	example.com/hello	(devel)	
+	build	GOOS=windows
+/usr/bin/old: go1.17.13
+`
+
+func TestParseGoVersionM(t *testing.T) {
+	reports, err := ParseGoVersionM(goVersionM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 3 {
+		t.Fatalf("ParseGoVersionM() returned %d reports, want 3", len(reports))
+	}
+
+	r := reports[0]
+	if r.File != "/usr/local/bin/stringer" || r.Version.String() != "go1.22.1" || r.Info.GoVersion != "go1.22.1" {
+		t.Errorf("reports[0] = %s, %v, %q", r.File, r.Version, r.Info.GoVersion)
+	}
+	bi := r.Info
+	if bi.Path != "golang.org/x/tools/cmd/stringer" || bi.Main.Path != "golang.org/x/tools" || bi.Main.Version != "v0.19.0" {
+		t.Errorf("reports[0] path, main = %q, %+v", bi.Path, bi.Main)
+	}
+	if len(bi.Deps) != 2 || bi.Deps[0].Path != "golang.org/x/mod" || bi.Deps[1].Replace == nil || bi.Deps[1].Replace.Path != "../sync" {
+		t.Errorf("reports[0] deps = %+v", bi.Deps)
+	}
+	if len(bi.Settings) != 4 || bi.Settings[3].Key != "GOOS" || bi.Settings[3].Value != "linux" {
+		t.Errorf("reports[0] settings = %+v", bi.Settings)
+	}
+
+	r = reports[1]
+	if r.File != `C:\Users\gopher\go\bin\hello.exe` || !r.Version.Devel || r.Version.Lang().String() != "go1.23" ||
+		r.Info.GoVersion != "devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000" || r.Info.Main.Version != "(devel)" {
+		t.Errorf("reports[1] = %s, %+v, %+v", r.File, r.Version, r.Info)
+	}
+
+	r = reports[2]
+	if r.File != "/usr/bin/old" || r.Version.String() != "go1.17.13" || r.Info.Path != "" {
+		t.Errorf("reports[2] = %s, %v, %+v", r.File, r.Version, r.Info)
+	}
+
+	for _, bad := range []string{
+		"\tpath\tfoo\n",
+		"/bin/x go1.22.1\n",
+		"/bin/x: 1.22.1\n",
+		"/bin/x: go1.22.1\n\tbuild\tnovalue\n",
+	} {
+		if _, err := ParseGoVersionM(bad); err == nil {
+			t.Errorf("ParseGoVersionM(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestBinaryVersion(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+	v, err := BinaryVersion(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ParseRuntime(runtime.Version())
+	if err != nil {
+		t.Skip(err)
+	}
+	if v != want {
+		t.Errorf("BinaryVersion(test binary) = %+v, want %+v", v, want)
+	}
+
+	file := filepath.Join(t.TempDir(), "notexe")
+	os.WriteFile(file, []byte("#!/bin/sh\n"), 0o755)
+	if _, err := BinaryVersion(file); err == nil {
+		t.Errorf("BinaryVersion(shell script) succeeded, want error")
+	}
+}
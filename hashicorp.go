@@ -0,0 +1,94 @@
+package gover
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A HashicorpVersion is a version as represented by
+// github.com/hashicorp/go-version, whose *version.Version implements
+// the interface. Declaring the methods here lets this package convert such
+// versions without depending on that module.
+type HashicorpVersion interface {
+	Segments() []int    // numeric components, at least three
+	Prerelease() string // prerelease, without the "-", or ""
+	Metadata() string   // build metadata, without the "+", or ""
+}
+
+// FromHashicorp returns the Go version corresponding to h,
+// as mapped by [FromSemver]: hashicorp/go-version's "1.21.3", "1.21.0-rc.2",
+// and "1.21.0-0" become go1.21.3, go1.21rc2, and go1.21, and build metadata
+// becomes a custom suffix, as in go1.21.3-bigcorp.
+// It returns an error wrapping [ErrUntranslatable] if h names no Go
+// version, as when it has a fourth non-zero segment.
+func FromHashicorp(h HashicorpVersion) (Version, error) {
+	segs := h.Segments()
+	for len(segs) < 3 {
+		segs = append(segs, 0)
+	}
+	var nums []string
+	for i, n := range segs {
+		if i >= 3 && n != 0 {
+			return Version{}, fmt.Errorf("version %v: %w: more than three numbers", segs, ErrUntranslatable)
+		}
+		if n < 0 {
+			return Version{}, fmt.Errorf("version %v: negative number", segs)
+		}
+		if i < 3 {
+			nums = append(nums, strconv.Itoa(n))
+		}
+	}
+	s := strings.Join(nums, ".")
+	if pre := h.Prerelease(); pre != "" {
+		s += "-" + pre
+	}
+	if meta := h.Metadata(); meta != "" {
+		s += "+" + meta
+	}
+	x := FromSemver(s)
+	if x == "" {
+		return Version{}, fmt.Errorf("version %s: %w: not a Go version", s, ErrUntranslatable)
+	}
+	return Parse(x)
+}
+
+// ToHashicorp converts v to a hashicorp/go-version value by passing the
+// semantic version that [ToSemver] maps it to, without the "v" prefix,
+// to newVersion, which is normally version.NewVersion:
+//
+//	hv, err := gover.ToHashicorp(v, version.NewVersion)
+//
+// The conversion preserves the order of versions, as compared by
+// [Version.Compare], since hashicorp/go-version orders prereleases as
+// semantic versioning does; see ToSemver for details.
+// Any experiments in v are dropped. Development versions and custom
+// suffixes that are not valid build metadata have no semantic version,
+// and ToHashicorp returns an error wrapping [ErrUntranslatable] for them.
+func ToHashicorp[H any](v Version, newVersion func(string) (H, error)) (H, error) {
+	var zero H
+	v.Experiment = ""
+	s := ""
+	if !v.Devel {
+		s = ToSemver(v.String())
+	}
+	if s == "" {
+		return zero, fmt.Errorf("version %v: %w: no semantic version", v, ErrUntranslatable)
+	}
+	return newVersion(strings.TrimPrefix(s, "v"))
+}
+
+// CompareHashicorp compares the Go version v with the hashicorp/go-version
+// value h, as converted by [FromHashicorp], returning -1, 0, or +1 as
+// with [Version.Compare], so that versions from configuration parsed by
+// hashicorp/go-version can be checked against Go toolchain versions.
+// Note that hashicorp/go-version reads "1.21" as 1.21.0, the release,
+// not the language version go1.21.
+// It returns an error if FromHashicorp cannot convert h.
+func CompareHashicorp(v Version, h HashicorpVersion) (int, error) {
+	w, err := FromHashicorp(h)
+	if err != nil {
+		return 0, err
+	}
+	return v.Compare(w), nil
+}
@@ -0,0 +1,95 @@
+package gover
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// hcVersion is a minimal stand-in for hashicorp/go-version's *version.Version.
+type hcVersion struct {
+	segs      []int
+	pre, meta string
+}
+
+func (h *hcVersion) Segments() []int    { return h.segs }
+func (h *hcVersion) Prerelease() string { return h.pre }
+func (h *hcVersion) Metadata() string   { return h.meta }
+
+// newHCVersion parses s as version.NewVersion does for semantic versions,
+// padding the numbers to three.
+func newHCVersion(s string) (*hcVersion, error) {
+	s = strings.TrimPrefix(s, "v")
+	h := new(hcVersion)
+	s, h.meta, _ = strings.Cut(s, "+")
+	s, h.pre, _ = strings.Cut(s, "-")
+	for _, f := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("malformed version: %s", s)
+		}
+		h.segs = append(h.segs, n)
+	}
+	for len(h.segs) < 3 {
+		h.segs = append(h.segs, 0)
+	}
+	return h, nil
+}
+
+func TestHashicorp(t *testing.T) {
+	for _, tt := range semverTests {
+		v := MustParse(tt.goVersion)
+		h, err := ToHashicorp(v, newHCVersion)
+		if err != nil {
+			t.Errorf("ToHashicorp(%v): %v", v, err)
+			continue
+		}
+		back, err := FromHashicorp(h)
+		if err != nil || back != v {
+			t.Errorf("FromHashicorp(ToHashicorp(%v)) = %#v, %v, want %#v", v, back, err, v)
+		}
+		if c, err := CompareHashicorp(v, h); c != 0 || err != nil {
+			t.Errorf("CompareHashicorp(%v, ToHashicorp(%v)) = %d, %v, want 0", v, v, c, err)
+		}
+	}
+
+	h, _ := newHCVersion("1.21")
+	for x, want := range map[string]int{"go1.21": -1, "go1.21rc1": -1, "go1.21.0": 0, "go1.21.1": +1} {
+		if c, err := CompareHashicorp(MustParse(x), h); c != want || err != nil {
+			t.Errorf("CompareHashicorp(%s, 1.21) = %d, %v, want %d", x, c, err, want)
+		}
+	}
+
+	for _, h := range []*hcVersion{
+		{segs: []int{1, 21, 3, 1}},
+		{segs: []int{1, 21, 0}, pre: "rc.1.2"},
+		{segs: []int{1, 21, 3}, pre: "0"},
+	} {
+		if v, err := FromHashicorp(h); !errors.Is(err, ErrUntranslatable) {
+			t.Errorf("FromHashicorp(%+v) = %v, %v, want ErrUntranslatable", h, v, err)
+		}
+	}
+	if v, err := FromHashicorp(&hcVersion{segs: []int{1, 21, 3, 0}}); err != nil || v != MustParse("go1.21.3") {
+		t.Errorf("FromHashicorp(1.21.3.0) = %v, %v, want go1.21.3", v, err)
+	}
+	if _, err := CompareHashicorp(MustParse("go1.21"), &hcVersion{segs: []int{1, -1, 0}}); err == nil {
+		t.Errorf("CompareHashicorp with negative segment succeeded")
+	}
+
+	dev, err := ParseRuntime("devel go1.23-2b1f1e8 Tue Jul 2 18:27:20 2024 +0000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h, err := ToHashicorp(dev, newHCVersion); !errors.Is(err, ErrUntranslatable) {
+		t.Errorf("ToHashicorp(%v) = %v, %v, want ErrUntranslatable", dev, h, err)
+	}
+	x, err := ParseRuntime("go1.21.0 X:loopvar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h, err := ToHashicorp(x, newHCVersion); err != nil || h.pre != "" || len(h.segs) != 3 || h.segs[1] != 21 {
+		t.Errorf("ToHashicorp(%v) = %+v, %v, want 1.21.0", x, h, err)
+	}
+}
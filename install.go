@@ -0,0 +1,143 @@
+package gover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// unpackedMarker is the file that marks a completely installed GOROOT.
+// It has the same name as the marker written by the golang.org/dl wrappers,
+// such as go1.22.1, so that they and Install recognize each other's SDKs.
+const unpackedMarker = ".unpacked-success"
+
+// Install installs Go version v for the current system, as described by
+// runtime.GOOS and runtime.GOARCH, using the download server configured
+// for the zero Client. Use [Client.Install] to choose another server.
+func Install(ctx context.Context, v, dir string) (string, error) {
+	return new(Client).Install(ctx, v, dir)
+}
+
+// Install installs Go version v for the current system, as described by
+// runtime.GOOS and runtime.GOARCH, from c's download server, and returns
+// the root of the installed toolchain, suitable for use as GOROOT.
+//
+// Install lays out toolchains as the golang.org/dl wrappers do:
+// version v is installed in the subdirectory of dir named by its
+// canonical version, such as dir/go1.22.1, and dir defaults to the
+// "sdk" subdirectory of the user's home directory.
+// If v is already installed there, Install returns its root immediately.
+//
+// Install downloads the binary distribution archive into dir, as by
+// [Client.Download], so that an interrupted installation resumes
+// where it left off; verifies its checksum against the one listed by the
+// download server; and extracts it, as by [Extract].
+// A root is only reported as installed once it is complete.
+func (c *Client) Install(ctx context.Context, v, dir string) (string, error) {
+	root, done, err := sdkRoot(v, dir)
+	if err != nil || done {
+		return root, err
+	}
+	dir = filepath.Dir(root)
+
+	name, err := ArchiveName(v, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return "", err
+	}
+	file, err := c.File(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	archive := filepath.Join(dir, name)
+	if err := c.Download(ctx, name, archive, nil); err != nil {
+		return "", err
+	}
+	if err := verifyFile(archive, file.SHA256); err != nil {
+		// Don't resume from a corrupt download next time.
+		os.Remove(archive)
+		return "", err
+	}
+
+	return unpack(archive, root, "go", false)
+}
+
+// sdkRoot returns the directory in dir in which Install installs version v,
+// and whether the version is completely installed there.
+func sdkRoot(v, dir string) (root string, done bool, err error) {
+	if !IsValid(v) {
+		return "", false, fmt.Errorf("invalid version %q", v)
+	}
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false, err
+		}
+		dir = filepath.Join(home, "sdk")
+	}
+	root = filepath.Join(dir, MustParse(v).Canonical())
+	if _, err := os.Stat(filepath.Join(root, unpackedMarker)); err == nil {
+		return root, true, nil
+	}
+	return root, false, nil
+}
+
+// unpack extracts the toolchain in the directory top of archive into root
+// and removes the archive. If setExec is set, because the archive does not
+// record file modes, as module zip files do not, unpack makes the commands in
+// the toolchain's bin and pkg/tool directories executable.
+func unpack(archive, root, top string, setExec bool) (string, error) {
+	// Extract into a temporary directory and move the result into place,
+	// replacing any earlier, incomplete installation.
+	tmp, err := os.MkdirTemp(filepath.Dir(root), ".install-"+filepath.Base(root)+"-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	if err := Extract(archive, tmp); err != nil {
+		return "", err
+	}
+	goroot := filepath.Join(tmp, filepath.FromSlash(top))
+	if _, err := os.Stat(filepath.Join(goroot, "VERSION")); err != nil {
+		return "", fmt.Errorf("installing %s: archive has no %s/VERSION file", filepath.Base(root), top)
+	}
+	if setExec {
+		if err := setExecutable(goroot); err != nil {
+			return "", err
+		}
+	}
+	if err := os.WriteFile(filepath.Join(goroot, unpackedMarker), nil, 0o644); err != nil {
+		return "", err
+	}
+	if err := os.RemoveAll(root); err != nil {
+		return "", err
+	}
+	if err := os.Rename(goroot, root); err != nil {
+		return "", err
+	}
+	os.Remove(archive)
+	return root, nil
+}
+
+// setExecutable makes the files in the bin and pkg/tool directories
+// of goroot executable.
+func setExecutable(goroot string) error {
+	for _, dir := range []string{"bin", filepath.Join("pkg", "tool")} {
+		err := filepath.WalkDir(filepath.Join(goroot, dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return err
+			}
+			return os.Chmod(path, 0o755)
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package gover
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// newInstallClient returns a client whose download server publishes
+// go1.22.1 for the current system as an archive with the given entries.
+// If sum is not empty, the server lists it as the archive's checksum.
+func newInstallClient(t *testing.T, entries []archiveEntry, sum string) (*Client, *int) {
+	t.Helper()
+	name, err := ArchiveName("go1.22.1", runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), name)
+	if strings.HasSuffix(name, ".zip") {
+		writeZip(t, file, entries)
+	} else {
+		writeTarGz(t, file, entries)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum == "" {
+		h := sha256.Sum256(data)
+		sum = hex.EncodeToString(h[:])
+	}
+	index, err := json.Marshal([]Release{{
+		Version: "go1.22.1",
+		Stable:  true,
+		Files:   []File{{Filename: name, OS: runtime.GOOS, Arch: runtime.GOARCH, Version: "go1.22.1", SHA256: sum, Kind: KindArchive}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	downloads := new(int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Write(index)
+		case "/" + name:
+			*downloads++
+			w.Write(data)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return &Client{CacheDir: "off", BaseURL: srv.URL}, downloads
+}
+
+var sdkArchive = []archiveEntry{
+	{"go/VERSION", 0o644, "go1.22.1\ntime 2024-03-05T22:33:05Z\n"},
+	{"go/bin/go", 0o755, "#!/bin/sh\n"},
+}
+
+func TestInstall(t *testing.T) {
+	c, downloads := newInstallClient(t, sdkArchive, "")
+	dir := t.TempDir()
+	ctx := context.Background()
+	root, err := c.Install(ctx, "go1.22.1", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "go1.22.1"); root != want {
+		t.Errorf("Install() = %q, want %q", root, want)
+	}
+	for _, name := range []string{"VERSION", "bin/go", unpackedMarker} {
+		if _, err := os.Stat(filepath.Join(root, name)); err != nil {
+			t.Errorf("installed root: %v", err)
+		}
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Install left %d entries in %s, want 1", len(entries), dir)
+	}
+
+	// A second installation finds the first.
+	if root2, err := c.Install(ctx, "go1.22.1", dir); root2 != root || err != nil {
+		t.Errorf("second Install() = %q, %v, want %q, nil", root2, err, root)
+	}
+	if *downloads != 1 {
+		t.Errorf("downloaded archive %d times, want 1", *downloads)
+	}
+
+	// An incomplete installation is replaced.
+	os.Remove(filepath.Join(root, unpackedMarker))
+	os.WriteFile(filepath.Join(root, "junk"), nil, 0o644)
+	if _, err := c.Install(ctx, "go1.22.1", dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "junk")); err == nil {
+		t.Errorf("Install kept files of an incomplete installation")
+	}
+}
+
+func TestInstallErrors(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newInstallClient(t, sdkArchive, strings.Repeat("0", 64))
+	dir := t.TempDir()
+	if _, err := c.Install(ctx, "go1.22.1", dir); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Install with bad checksum: %v, want checksum mismatch", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Install with bad checksum left %d entries in %s", len(entries), dir)
+	}
+	if _, err := c.Install(ctx, "go1.21.0", dir); err == nil {
+		t.Errorf("Install of unlisted version succeeded")
+	}
+	if _, err := c.Install(ctx, "1.22", dir); err == nil {
+		t.Errorf("Install of invalid version succeeded")
+	}
+
+	c, _ = newInstallClient(t, []archiveEntry{{"go/bin/go", 0o755, ""}}, "")
+	if _, err := c.Install(ctx, "go1.22.1", dir); err == nil {
+		t.Errorf("Install of archive without VERSION succeeded")
+	}
+}
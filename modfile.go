@@ -0,0 +1,398 @@
+package gover
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// keepVersion is a modfile.VersionFixer that accepts versions as written,
+// since reading the go and toolchain directives does not involve them.
+func keepVersion(path, vers string) (string, error) { return vers, nil }
+
+// ModuleRequires returns the go and toolchain directives of the go.mod
+// file with the given contents, as versions with the "go" prefix used
+// throughout this package: the directives
+//
+//	go 1.21
+//	toolchain go1.22.3
+//
+// are returned as "go1.21" and "go1.22.3". A missing directive is returned
+// as the empty string, and "toolchain default" as "default".
+// Statements unknown to this package, such as those added by newer
+// versions of Go, are ignored.
+func ModuleRequires(data []byte) (goVersion, toolchain string, err error) {
+	goStmt, tc, err := parseModFile(data)
+	if err != nil {
+		return "", "", err
+	}
+	return directives(goStmt, tc)
+}
+
+// parseModFile returns the go and toolchain statements of the go.mod file
+// with the given contents, or nil for missing statements.
+func parseModFile(data []byte) (*modfile.Go, *modfile.Toolchain, error) {
+	f, err := modfile.ParseLax("go.mod", data, keepVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	// ParseLax ignores the toolchain directive, which applies only to
+	// the main module, so find it in the syntax tree.
+	tc := f.Toolchain
+	for _, stmt := range f.Syntax.Stmt {
+		if line, ok := stmt.(*modfile.Line); tc == nil && ok && len(line.Token) > 0 && line.Token[0] == "toolchain" {
+			if len(line.Token) != 2 {
+				return nil, nil, fmt.Errorf("go.mod:%d: usage: toolchain name", line.Start.Line)
+			}
+			tc = &modfile.Toolchain{Name: line.Token[1], Syntax: line}
+		}
+	}
+	return f.Go, tc, nil
+}
+
+// directives returns the go and toolchain directives go and tc,
+// one or both of which may be nil, in the form described for ModuleRequires.
+func directives(goStmt *modfile.Go, tc *modfile.Toolchain) (goVersion, toolchain string, err error) {
+	if goStmt != nil {
+		goVersion = "go" + goStmt.Version
+		if !IsValid(goVersion) {
+			return "", "", fmt.Errorf("invalid go directive %q", goStmt.Version)
+		}
+	}
+	if tc != nil {
+		toolchain = tc.Name
+		if toolchain != "default" && !IsValid(toolchain) {
+			return "", "", fmt.Errorf("invalid toolchain directive %q", tc.Name)
+		}
+	}
+	return goVersion, toolchain, nil
+}
+
+// GoDirective returns the go directive of the go.mod or go.work file path,
+// as a version with the "go" prefix, such as "go1.21", or the empty string
+// if the file has no go directive. Files named go.work are parsed as
+// workspace files; any other file is parsed as a go.mod file.
+func GoDirective(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	var goVersion string
+	if filepath.Base(path) == "go.work" {
+		f, err := modfile.ParseWork(path, data, keepVersion)
+		if err != nil {
+			return "", err
+		}
+		goVersion, _, err = directives(f.Go, nil)
+	} else {
+		goVersion, _, err = ModuleRequires(data)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", path, err)
+	}
+	return goVersion, nil
+}
+
+// Supports reports whether toolchain, such as "go1.22.1", can build a
+// module whose go directive is goVersion, such as "go1.22" or "go1.22.3".
+// The go command refuses to build a module that requires a newer Go
+// version than its own. Note that go1.21rc1 can build modules that say
+// "go 1.21", but not "go 1.21.0".
+// Development toolchains, in the form accepted by ParseRuntime, support
+// every go directive up to their language version under development.
+// Supports reports false if either version is invalid.
+func Supports(toolchain, goVersion string) bool {
+	t, err := ParseRuntime(toolchain)
+	if err != nil || !IsValid(goVersion) {
+		return false
+	}
+	if t.Devel {
+		return Compare(Lang(goVersion), t.Lang().Canonical()) <= 0
+	}
+	return t.Compare(MustParse(goVersion)) >= 0
+}
+
+// RuntimeSupports returns an error, worded like the go command's own,
+// unless the running Go toolchain, as reported by [runtime.Version],
+// can build a module whose go directive is goVersion; see [Supports].
+func RuntimeSupports(goVersion string) error {
+	if !IsValid(goVersion) {
+		return fmt.Errorf("invalid go directive %q", goVersion)
+	}
+	running := runtimeVersion()
+	if !Supports(running, goVersion) {
+		return fmt.Errorf("go.mod requires go >= %s (running go %s)", stripGo(goVersion), stripGo(running))
+	}
+	return nil
+}
+
+// ValidGoDirective returns an error unless s, the argument of a go
+// directive as written in a go.mod file, such as "1.21" or "1.22.3",
+// is accepted by the go command of version era.
+// Go 1.21 and later accept a release, such as "1.21.0", or a language
+// version, such as "1.21", optionally followed by a prerelease,
+// as in "1.21rc1"; earlier toolchains, starting with Go 1.12, accept
+// only the two-part form "1.N", and reject go.mod files whose go
+// directive has a patch number or a prerelease.
+// No toolchain accepts a "go" prefix, a custom suffix such as "-bigcorp",
+// or numbers with leading zeros.
+// If era is the zero Version, ValidGoDirective applies the current rules.
+func ValidGoDirective(s string, era Version) error {
+	modern := era == (Version{}) || era.Lang().Compare(MustParse("go1.21")) >= 0
+	format := "1.23"
+	if modern {
+		format = "1.23.0"
+	}
+	bad := func(reason string) error {
+		return fmt.Errorf("invalid go version %q: %s", s, reason)
+	}
+	switch {
+	case strings.HasPrefix(s, "go"):
+		return bad("omit the go prefix, as in \"" + strings.TrimPrefix(s, "go") + "\"")
+	case strings.Contains(s, "-"):
+		return bad("toolchain suffixes are not allowed")
+	}
+
+	// Mirror the go command's regular expression,
+	// ^([1-9][0-9]*)\.(0|[1-9][0-9]*)(\.(0|[1-9][0-9]*))?([a-z]+[0-9]+)?$,
+	// of which toolchains before Go 1.21 accept only the first two parts.
+	major, rest, ok := cutInt(s)
+	if !ok || major == "0" || !strings.HasPrefix(rest, ".") {
+		return bad("must match format " + format)
+	}
+	_, rest, ok = cutInt(rest[1:])
+	if !ok {
+		return bad("must match format " + format)
+	}
+	patch := false
+	if strings.HasPrefix(rest, ".") {
+		if _, rest, ok = cutInt(rest[1:]); !ok {
+			return bad("must match format " + format)
+		}
+		patch = true
+	}
+	pre := false
+	if rest != "" {
+		i := 0
+		for i < len(rest) && 'a' <= rest[i] && rest[i] <= 'z' {
+			i++
+		}
+		j := i
+		for j < len(rest) && '0' <= rest[j] && rest[j] <= '9' {
+			j++
+		}
+		if i == 0 || j == i || j != len(rest) {
+			return bad("must match format " + format)
+		}
+		pre = true
+	}
+	switch {
+	case !modern && patch:
+		return bad(fmt.Sprintf("must match format %s; %s does not accept a patch number, which requires go1.21 or later", format, era.Canonical()))
+	case !modern && pre:
+		return bad(fmt.Sprintf("must match format %s; %s does not accept a prerelease, which requires go1.21 or later", format, era.Canonical()))
+	}
+	return nil
+}
+
+// A FindingKind classifies a problem found by [CheckDirectives].
+type FindingKind int
+
+const (
+	InvalidGo            FindingKind = 1 + iota // go directive is not a valid version
+	InvalidToolchain                            // toolchain directive is not a valid toolchain name
+	LangToolchain                               // toolchain directive names a language version, not a release
+	ToolchainOlderThanGo                        // toolchain directive is older than the go directive, so it is ignored
+	RedundantToolchain                          // toolchain directive is the same as the go directive
+)
+
+var findingKindText = [...]string{
+	InvalidGo:            "invalid go directive",
+	InvalidToolchain:     "invalid toolchain directive",
+	LangToolchain:        "toolchain is a language version",
+	ToolchainOlderThanGo: "toolchain older than go",
+	RedundantToolchain:   "redundant toolchain",
+}
+
+func (k FindingKind) String() string {
+	if 0 < k && int(k) < len(findingKindText) {
+		return findingKindText[k]
+	}
+	return fmt.Sprintf("FindingKind(%d)", int(k))
+}
+
+// A Finding is a problem with the go and toolchain directives of a go.mod
+// or go.work file, reported by [CheckDirectives].
+type Finding struct {
+	Kind    FindingKind
+	Message string // explanation, suggesting a fix where there is one
+}
+
+func (f Finding) String() string {
+	return f.Kind.String() + ": " + f.Message
+}
+
+// CheckDirectives checks that the go and toolchain directives of a
+// go.mod or go.work file, in the form returned by [ModuleRequires],
+// such as "go1.21" and "go1.22.3", are consistent, and returns the
+// problems it finds, if any. Empty directives are not checked.
+//
+// The toolchain directive must be "default" or a toolchain name, which is
+// "go" followed by a version and optionally by a custom suffix, as in
+// "go1.22.3-bigcorp", but no "+auto" or "+path" suffix. Since Go 1.21, it
+// must name a release or prerelease rather than a language version, since
+// "go1.21" (unlike "go 1.21") names no toolchain. And it must be newer than
+// the go directive: the go command ignores an older toolchain directive,
+// and "go mod tidy" removes one that equals the go directive.
+func CheckDirectives(goVersion, toolchain string) []Finding {
+	var findings []Finding
+	if goVersion != "" && !IsValid(goVersion) {
+		findings = append(findings, Finding{InvalidGo, fmt.Sprintf("%q is not a valid Go version", stripGo(goVersion))})
+		goVersion = ""
+	}
+	if toolchain == "" || toolchain == "default" {
+		return findings
+	}
+	if _, err := Parse(toolchain); err != nil {
+		msg := fmt.Sprintf("%q is not a valid toolchain name", toolchain)
+		switch {
+		case IsValid("go" + toolchain):
+			msg += fmt.Sprintf("; use go%s", toolchain)
+		case strings.Contains(toolchain, "+"):
+			msg += "; +auto and +path belong only in GOTOOLCHAIN"
+		}
+		return append(findings, Finding{InvalidToolchain, msg})
+	}
+	if IsLang(toolchain) {
+		findings = append(findings, Finding{LangToolchain, fmt.Sprintf("%s names a language version, not a toolchain; use %s.0", toolchain, toolchain)})
+	}
+	if goVersion != "" {
+		switch c := Compare(toolchain, goVersion); {
+		case c == 0 && CompareDetail(toolchain, goVersion) != 0:
+			// A custom toolchain, such as go1.22.3-bigcorp, differs from go1.22.3.
+		case c < 0:
+			findings = append(findings, Finding{ToolchainOlderThanGo, fmt.Sprintf("toolchain %s is older than go %s, so the go command ignores it; remove it or raise it above go %s", toolchain, stripGo(goVersion), stripGo(goVersion))})
+		case c == 0:
+			findings = append(findings, Finding{RedundantToolchain, fmt.Sprintf("toolchain %s does not differ from go %s; remove it", toolchain, stripGo(goVersion))})
+		}
+	}
+	return findings
+}
+
+// CheckModFile checks the go and toolchain directives of the go.mod file
+// with the given contents, as described for [CheckDirectives].
+// It returns an error only if the file cannot be parsed.
+func CheckModFile(data []byte) ([]Finding, error) {
+	goStmt, tc, err := parseModFile(data)
+	if err != nil {
+		return nil, err
+	}
+	var goVersion, toolchain string
+	if goStmt != nil {
+		goVersion = "go" + goStmt.Version
+	}
+	if tc != nil {
+		toolchain = tc.Name
+	}
+	return CheckDirectives(goVersion, toolchain), nil
+}
+
+// A directiveFile is a go.mod or go.work file being edited.
+type directiveFile interface {
+	AddGoStmt(version string) error
+	AddToolchainStmt(name string) error
+	DropToolchainStmt()
+}
+
+// BumpGoDirective raises the go directive of the go.mod or go.work file
+// path to newVersion, such as "go1.22.3", editing the file in place and
+// preserving its comments and formatting. A go directive already at or
+// above newVersion is left alone: BumpGoDirective never lowers it
+// (see [CanLowerDirective]). Files named go.work are edited as workspace
+// files; any other file is edited as a go.mod file.
+//
+// If alsoToolchain is set, BumpGoDirective also raises the toolchain
+// directive to newVersion, or to the first release of newVersion if it is
+// a language version, such as go1.22.0 for go1.22. A toolchain directive
+// left no newer than the go directive is removed, as "go mod tidy" would,
+// since the go command ignores it.
+// The file is only written if a directive changes.
+func BumpGoDirective(path, newVersion string, alsoToolchain bool) error {
+	if !IsValid(newVersion) {
+		return fmt.Errorf("invalid version %q", newVersion)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var (
+		f      directiveFile
+		goStmt *modfile.Go
+		tc     *modfile.Toolchain
+		syntax *modfile.FileSyntax
+	)
+	if filepath.Base(path) == "go.work" {
+		wf, err := modfile.ParseWork(path, data, keepVersion)
+		if err != nil {
+			return err
+		}
+		f, goStmt, tc, syntax = wf, wf.Go, wf.Toolchain, wf.Syntax
+	} else {
+		mf, err := modfile.Parse(path, data, keepVersion)
+		if err != nil {
+			return err
+		}
+		f, goStmt, tc, syntax = mf, mf.Go, mf.Toolchain, mf.Syntax
+	}
+
+	changed := false
+	goVersion := ""
+	if goStmt != nil {
+		goVersion = "go" + goStmt.Version
+	}
+	if goVersion == "" || Compare(newVersion, goVersion) > 0 {
+		goVersion, changed = newVersion, true
+		if err := f.AddGoStmt(stripGo(newVersion)); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	if alsoToolchain {
+		old := ""
+		if tc != nil {
+			old = tc.Name
+		}
+		toolchain, want := old, newVersion
+		if IsLang(want) {
+			want += ".0"
+		}
+		if toolchain == "" || toolchain == "default" || IsValid(toolchain) && Compare(want, toolchain) > 0 {
+			toolchain = want
+		}
+		switch {
+		case IsValid(toolchain) && CompareDetail(toolchain, goVersion) <= 0:
+			if tc != nil {
+				f.DropToolchainStmt()
+				changed = true
+			}
+		case toolchain != old:
+			if err := f.AddToolchainStmt(toolchain); err != nil {
+				return fmt.Errorf("%s: %v", path, err)
+			}
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	// Remove the dropped toolchain line. Unlike the files' Cleanup methods,
+	// this leaves the rest of the file, such as one-line blocks, as it was.
+	syntax.Stmt = slices.DeleteFunc(syntax.Stmt, func(stmt modfile.Expr) bool {
+		line, ok := stmt.(*modfile.Line)
+		return ok && len(line.Token) == 0
+	})
+	return os.WriteFile(path, modfile.Format(syntax), 0o666)
+}
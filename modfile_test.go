@@ -0,0 +1,283 @@
+package gover
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var moduleRequiresTests = []struct {
+	in        string
+	goVersion string
+	toolchain string
+	err       bool
+}{
+	{"module example.com/m\n\ngo 1.21\n\ntoolchain go1.22.3\n", "go1.21", "go1.22.3", false},
+	{"module example.com/m\n\ngo 1.22.1\n", "go1.22.1", "", false},
+	{"module example.com/m\n\ngo 1.21rc1\n", "go1.21rc1", "", false},
+	{"module example.com/m\n\ngo 1.16\n\nrequire example.com/x v1.2.3\n", "go1.16", "", false},
+	{"module example.com/m\n\ngo 1.22\ntoolchain default\n", "go1.22", "default", false},
+	{"module example.com/m\n", "", "", false},
+	{"module example.com/m\ngo 1.23\nfuturestatement x\n", "go1.23", "", false},
+	{"module example.com/m\ngo 1.23\ntoolchain 1.23.1\n", "", "", true},
+	{"module example.com/m\ngo 1.021\n", "", "", true},
+	{"module example.com/m\ngo\n", "", "", true},
+}
+
+func TestModuleRequires(t *testing.T) {
+	for _, tt := range moduleRequiresTests {
+		goVersion, toolchain, err := ModuleRequires([]byte(tt.in))
+		if goVersion != tt.goVersion || toolchain != tt.toolchain || (err != nil) != tt.err {
+			t.Errorf("ModuleRequires(%q) = %q, %q, %v, want %q, %q, err=%v", tt.in, goVersion, toolchain, err, tt.goVersion, tt.toolchain, tt.err)
+		}
+	}
+}
+
+func TestGoDirective(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":       "module example.com/m\n\ngo 1.22.1\n",
+		"go.work":      "go 1.23\n\nuse ./m\n",
+		"other.mod":    "module example.com/other\n",
+		"bad/go.mod":   "module example.com/m\ngo 1.x\n",
+		"bad/go.work":  "go 1.23\nmodule x\n",
+		"m/go.mod.bak": "module example.com/m\n\ngo 1.20\n",
+	}
+	for name, data := range files {
+		os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o777)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tt := range []struct {
+		name, out string
+		err       bool
+	}{
+		{"go.mod", "go1.22.1", false},
+		{"go.work", "go1.23", false},
+		{"other.mod", "", false},
+		{"m/go.mod.bak", "go1.20", false},
+		{"bad/go.mod", "", true},
+		{"bad/go.work", "", true},
+		{"missing/go.mod", "", true},
+	} {
+		out, err := GoDirective(filepath.Join(dir, tt.name))
+		if out != tt.out || (err != nil) != tt.err {
+			t.Errorf("GoDirective(%s) = %q, %v, want %q, err=%v", tt.name, out, err, tt.out, tt.err)
+		}
+	}
+}
+
+var supportsTests = []struct {
+	toolchain, goVersion string
+	out                  bool
+}{
+	{"go1.22.1", "go1.22", true},
+	{"go1.22.1", "go1.22.1", true},
+	{"go1.22.1", "go1.22.2", false},
+	{"go1.22.1", "go1.23", false},
+	{"go1.21rc1", "go1.21", true},
+	{"go1.21rc1", "go1.21.0", false},
+	{"go1.22.1", "go1.16", true},
+	{"go1.20", "go1.20", true},
+	{"go1.21.0 X:loopvar", "go1.21.0", true},
+	{"devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000", "go1.23.4", true},
+	{"devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000", "go1.24", false},
+	{"go1.22.1", "1.22", false},
+	{"1.22.1", "go1.22", false},
+}
+
+func TestSupports(t *testing.T) {
+	for _, tt := range supportsTests {
+		if out := Supports(tt.toolchain, tt.goVersion); out != tt.out {
+			t.Errorf("Supports(%q, %q) = %v, want %v", tt.toolchain, tt.goVersion, out, tt.out)
+		}
+	}
+}
+
+func TestRuntimeSupports(t *testing.T) {
+	defer func(f func() string) { runtimeVersion = f }(runtimeVersion)
+	runtimeVersion = func() string { return "go1.22.1" }
+	if err := RuntimeSupports("go1.22"); err != nil {
+		t.Errorf("RuntimeSupports(go1.22) = %v", err)
+	}
+	want := "go.mod requires go >= 1.23.1 (running go 1.22.1)"
+	if err := RuntimeSupports("go1.23.1"); err == nil || err.Error() != want {
+		t.Errorf("RuntimeSupports(go1.23.1) = %v, want %q", err, want)
+	}
+	if err := RuntimeSupports("1.22"); err == nil {
+		t.Errorf("RuntimeSupports(1.22) succeeded, want error")
+	}
+}
+
+var validGoDirectiveTests = []struct {
+	s   string
+	era string // "" for the zero Version
+	ok  bool
+}{
+	{"1.21", "", true},
+	{"1.21.0", "", true},
+	{"1.22.3", "go1.22.3", true},
+	{"1.21rc1", "go1.21.0", true},
+	{"1.16", "go1.16.15", true},
+	{"1.16", "go1.23.0", true},
+	{"1.23", "go1.20.5", true},
+	{"2.0", "", true},
+	{"1.21.0", "go1.20.14", false},
+	{"1.21rc1", "go1.20.14", false},
+	{"1.21.0", "go1.21rc2", true},
+	{"go1.21", "", false},
+	{"1.21-bigcorp", "", false},
+	{"1", "", false},
+	{"0.1", "", false},
+	{"1.021", "", false},
+	{"1.21.", "", false},
+	{"1.21rc", "", false},
+	{"1.21rc1x", "", false},
+	{"1.21 ", "", false},
+	{"", "", false},
+}
+
+func TestValidGoDirective(t *testing.T) {
+	for _, tt := range validGoDirectiveTests {
+		var era Version
+		if tt.era != "" {
+			era = MustParse(tt.era)
+		}
+		err := ValidGoDirective(tt.s, era)
+		if (err == nil) != tt.ok {
+			t.Errorf("ValidGoDirective(%q, %s) = %v, want ok=%v", tt.s, tt.era, err, tt.ok)
+		}
+	}
+	err := ValidGoDirective("1.21.0", MustParse("go1.20"))
+	if want := `invalid go version "1.21.0": must match format 1.23; go1.20 does not accept a patch number, which requires go1.21 or later`; err == nil || err.Error() != want {
+		t.Errorf("ValidGoDirective(1.21.0, go1.20) = %v, want %s", err, want)
+	}
+}
+
+var checkDirectivesTests = []struct {
+	goVersion, toolchain string
+	kinds                []FindingKind
+}{
+	{"go1.21", "go1.22.3", nil},
+	{"go1.21", "go1.21.0", nil},
+	{"go1.22.1", "", nil},
+	{"", "go1.22.3", nil},
+	{"go1.22", "default", nil},
+	{"go1.21", "go1.21rc2", nil},
+	{"go1.22.3", "go1.22.3-bigcorp", nil},
+	{"go1.22.3", "go1.22.3", []FindingKind{RedundantToolchain}},
+	{"go1.22.3", "go1.22.1", []FindingKind{ToolchainOlderThanGo}},
+	{"go1.22.3", "go1.22", []FindingKind{LangToolchain, ToolchainOlderThanGo}},
+	{"go1.21", "go1.22", []FindingKind{LangToolchain}},
+	{"go1.19", "go1.20", nil},
+	{"go1.21", "1.22.3", []FindingKind{InvalidToolchain}},
+	{"go1.21", "go1.22.3+auto", []FindingKind{InvalidToolchain}},
+	{"go1.21", "local", []FindingKind{InvalidToolchain}},
+	{"go1.x", "go1.22.3", []FindingKind{InvalidGo}},
+}
+
+func TestCheckDirectives(t *testing.T) {
+	for _, tt := range checkDirectivesTests {
+		findings := CheckDirectives(tt.goVersion, tt.toolchain)
+		var kinds []FindingKind
+		for _, f := range findings {
+			kinds = append(kinds, f.Kind)
+			if f.Message == "" {
+				t.Errorf("CheckDirectives(%q, %q): %v has no message", tt.goVersion, tt.toolchain, f.Kind)
+			}
+		}
+		if !slices.Equal(kinds, tt.kinds) {
+			t.Errorf("CheckDirectives(%q, %q) = %v, want kinds %v", tt.goVersion, tt.toolchain, findings, tt.kinds)
+		}
+	}
+
+	f := CheckDirectives("go1.21", "1.22.3")
+	if want := `invalid toolchain directive: "1.22.3" is not a valid toolchain name; use go1.22.3`; len(f) != 1 || f[0].String() != want {
+		t.Errorf("CheckDirectives(go1.21, 1.22.3) = %v, want %q", f, want)
+	}
+}
+
+func TestCheckModFile(t *testing.T) {
+	findings, err := CheckModFile([]byte("module example.com/m\n\ngo 1.22.3\n\ntoolchain go1.22.1\n"))
+	if err != nil || len(findings) != 1 || findings[0].Kind != ToolchainOlderThanGo {
+		t.Errorf("CheckModFile(older toolchain) = %v, %v", findings, err)
+	}
+	findings, err = CheckModFile([]byte("module example.com/m\n\ngo 1.22.3\n\ntoolchain 1.23\n"))
+	if err != nil || len(findings) != 1 || findings[0].Kind != InvalidToolchain {
+		t.Errorf("CheckModFile(invalid toolchain) = %v, %v", findings, err)
+	}
+	if findings, err := CheckModFile([]byte("module example.com/m\n\ngo 1.22.3\n")); err != nil || findings != nil {
+		t.Errorf("CheckModFile(no toolchain) = %v, %v", findings, err)
+	}
+	if _, err := CheckModFile([]byte("module example.com/m\ngo 1.22.3\ntoolchain\n")); err == nil {
+		t.Errorf("CheckModFile(malformed) succeeded")
+	}
+}
+
+const bumpGoMod = `// Module m is an example.
+module example.com/m
+
+go 1.21 // minimum supported
+
+toolchain go1.21.5
+
+require (
+	example.com/x v1.2.3 // indirect
+)
+`
+
+var bumpGoDirectiveTests = []struct {
+	file          string
+	in            string
+	newVersion    string
+	alsoToolchain bool
+	out           string // "" for unchanged
+}{
+	{"go.mod", bumpGoMod, "go1.22.3", false,
+		strings.Replace(bumpGoMod, "go 1.21 //", "go 1.22.3 //", 1)},
+	{"go.mod", bumpGoMod, "go1.22", true,
+		strings.Replace(strings.Replace(bumpGoMod, "go 1.21 //", "go 1.22 //", 1), "go1.21.5", "go1.22.0", 1)},
+	{"go.mod", bumpGoMod, "go1.22.3", true,
+		strings.Replace(strings.Replace(bumpGoMod, "go 1.21 //", "go 1.22.3 //", 1), "toolchain go1.21.5\n\n", "", 1)},
+	{"go.mod", bumpGoMod, "go1.21", true, ""},
+	{"go.mod", bumpGoMod, "go1.20", false, ""},
+	{"go.mod", "module example.com/m\n", "go1.22.3", false, "module example.com/m\n\ngo 1.22.3\n"},
+	{"go.mod", "module example.com/m\n\ngo 1.21\n", "go1.22", true, "module example.com/m\n\ngo 1.22\n\ntoolchain go1.22.0\n"},
+	{"go.mod", "module example.com/m\n\ngo 1.21\n\ntoolchain go1.23.0\n", "go1.22.0", true, "module example.com/m\n\ngo 1.22.0\n\ntoolchain go1.23.0\n"},
+	{"go.work", "go 1.21\n\n// members\nuse ./m\n", "go1.22.1", false, "go 1.22.1\n\n// members\nuse ./m\n"},
+	{"go.work", "go 1.21\n\ntoolchain go1.21.5\n\nuse ./m\n", "go1.22.1", true, "go 1.22.1\n\nuse ./m\n"},
+}
+
+func TestBumpGoDirective(t *testing.T) {
+	for _, tt := range bumpGoDirectiveTests {
+		file := filepath.Join(t.TempDir(), tt.file)
+		if err := os.WriteFile(file, []byte(tt.in), 0o666); err != nil {
+			t.Fatal(err)
+		}
+		if err := BumpGoDirective(file, tt.newVersion, tt.alsoToolchain); err != nil {
+			t.Errorf("BumpGoDirective(%s, %s, %v): %v", tt.file, tt.newVersion, tt.alsoToolchain, err)
+			continue
+		}
+		data, _ := os.ReadFile(file)
+		want := tt.out
+		if want == "" {
+			want = tt.in
+		}
+		if string(data) != want {
+			t.Errorf("BumpGoDirective(%s, %s, %v) of\n%s\nwrote\n%s\nwant\n%s", tt.file, tt.newVersion, tt.alsoToolchain, tt.in, data, want)
+		}
+	}
+
+	file := filepath.Join(t.TempDir(), "go.mod")
+	os.WriteFile(file, []byte("module example.com/m\n"), 0o666)
+	if err := BumpGoDirective(file, "1.22", false); err == nil {
+		t.Errorf("BumpGoDirective with invalid version succeeded")
+	}
+	os.WriteFile(file, []byte("module example.com/m\nbogus\n"), 0o666)
+	if err := BumpGoDirective(file, "go1.22", false); err == nil {
+		t.Errorf("BumpGoDirective of malformed file succeeded")
+	}
+}
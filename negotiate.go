@@ -0,0 +1,50 @@
+package gover
+
+// Negotiate returns the highest version supported by both ours and theirs,
+// as in a handshake between a client and a server that each advertise
+// the toolchain versions they support.
+//
+// Each entry in ours and theirs is either a version, such as "go1.22.3",
+// or a constraint, such as ">=go1.21, <go1.23", as parsed by ParseConstraint.
+// A side supports a version if it lists the version or one of its
+// constraints matches the version. The candidates are the versions
+// listed by either side, so two lists of constraints alone do not
+// produce a result. Entries that are neither versions nor constraints are ignored.
+//
+// If no version is supported by both sides, Negotiate returns "", false.
+// Among candidates that compare equal, such as "go1.20" and "go1.20.0",
+// the first listed in ours, then theirs, is returned.
+func Negotiate(ours, theirs []string) (string, bool) {
+	oc, tc := negotiationSide(ours), negotiationSide(theirs)
+	best := ""
+	for _, list := range [][]string{ours, theirs} {
+		for _, v := range list {
+			if IsValid(v) && (best == "" || Compare(v, best) > 0) && supports(oc, v) && supports(tc, v) {
+				best = v
+			}
+		}
+	}
+	return best, best != ""
+}
+
+// negotiationSide parses the entries in list as constraints,
+// dropping those that do not parse.
+func negotiationSide(list []string) []Constraint {
+	var cs []Constraint
+	for _, s := range list {
+		if c, err := ParseConstraint(s); err == nil {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+// supports reports whether any constraint in cs matches v.
+func supports(cs []Constraint, v string) bool {
+	for _, c := range cs {
+		if c.Check(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package gover
+
+import "testing"
+
+var negotiateTests = []struct {
+	ours, theirs []string
+	out          string
+	ok           bool
+}{
+	{[]string{"go1.21.5", "go1.22.3", "go1.23.0"}, []string{"go1.22.3", "go1.21.5"}, "go1.22.3", true},
+	{[]string{"go1.21.5", "go1.22.3"}, []string{"go1.23.0"}, "", false},
+	{[]string{"go1.21.5", "go1.22.3", "go1.23.0"}, []string{"<go1.23"}, "go1.22.3", true},
+	{[]string{">=go1.21"}, []string{"go1.20.14", "go1.21.13", "go1.22rc1"}, "go1.22rc1", true},
+	{[]string{">=go1.21"}, []string{">=go1.22"}, "", false},
+	{[]string{">=go1.21", "go1.24.0"}, []string{">=go1.22", "go1.22.1", "go1.20.9"}, "go1.24.0", true},
+	{[]string{"go1.20"}, []string{"go1.20.0"}, "go1.20", true},
+	{[]string{"go1.21"}, []string{"go1.21.0"}, "", false},
+	{[]string{"bad", "go1.21.0"}, []string{"bad", "go1.21.0"}, "go1.21.0", true},
+	{nil, []string{"go1.21.0"}, "", false},
+}
+
+func TestNegotiate(t *testing.T) {
+	for _, tt := range negotiateTests {
+		out, ok := Negotiate(tt.ours, tt.theirs)
+		if out != tt.out || ok != tt.ok {
+			t.Errorf("Negotiate(%q, %q) = %q, %v, want %q, %v", tt.ours, tt.theirs, out, ok, tt.out, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package gover
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ReleaseNotesURL returns the URL of the documentation describing
+// what changed in the version v.
+// For a language version, the first release of one, or one of its
+// prereleases, that is the release notes for the language version;
+// for a later patch release, it is the entry in the release history.
+// For example:
+//
+//	ReleaseNotesURL("go1.22.0") = "https://go.dev/doc/go1.22"
+//	ReleaseNotesURL("go1.22rc1") = "https://go.dev/doc/go1.22"
+//	ReleaseNotesURL("go1.22.3") = "https://go.dev/doc/devel/release#go1.22.3"
+//	ReleaseNotesURL("go1.20") = "https://go.dev/doc/go1.20"
+//
+// If v is invalid, ReleaseNotesURL returns the empty string.
+func ReleaseNotesURL(v string) string {
+	x := parse(stripGo(v))
+	if x == (Version{}) {
+		return ""
+	}
+	if x.Patch == "" || x.Patch == "0" {
+		return "https://go.dev/doc/" + x.Lang().Canonical()
+	}
+	return "https://go.dev/doc/devel/release#" + Version{Major: x.Major, Minor: x.Minor, Patch: x.Patch}.Canonical()
+}
+
+// A ChangelogEntry describes one release in a Changelog.
+type ChangelogEntry struct {
+	Version    string    // such as "go1.22.3"
+	Date       time.Time // release date, or the zero time if unknown
+	Security   bool      // whether the release fixed security vulnerabilities
+	Advisories []string  // advisories fixed by the release, as by Advisories
+	NotesURL   string    // as by ReleaseNotesURL
+}
+
+// Changelog returns an entry for each release after from, up to and
+// including to, in increasing order, describing what an upgrade
+// from one to the other brings. For example, Changelog("go1.21.3", "go1.22.5")
+// lists go1.21.4 through go1.21.13 and go1.22.0 through go1.22.5.
+// The releases come from the release table embedded in the package,
+// as by Releases.
+// Changelog returns an error if from or to is invalid or from is newer than to.
+func Changelog(from, to string) ([]ChangelogEntry, error) {
+	for _, v := range []string{from, to} {
+		if !IsValid(v) {
+			return nil, fmt.Errorf("invalid version %q", v)
+		}
+	}
+	if Compare(from, to) > 0 {
+		return nil, fmt.Errorf("version %s is newer than %s", from, to)
+	}
+	var log []ChangelogEntry
+	for _, r := range releaseTable() {
+		if !r.stable || !Between(r.version, from, to, ExcludeLo) {
+			continue
+		}
+		log = append(log, ChangelogEntry{
+			Version:    r.version,
+			Date:       r.date,
+			Security:   len(r.advisories) > 0,
+			Advisories: slices.Clone(r.advisories),
+			NotesURL:   ReleaseNotesURL(r.version),
+		})
+	}
+	return log, nil
+}
@@ -0,0 +1,63 @@
+package gover
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestReleaseNotesURL(t *testing.T) {
+	test1(t, releaseNotesURLTests, "ReleaseNotesURL", ReleaseNotesURL)
+}
+
+var releaseNotesURLTests = []testCase1[string, string]{
+	{"go1.22.0", "https://go.dev/doc/go1.22"},
+	{"go1.22", "https://go.dev/doc/go1.22"},
+	{"go1.22rc1", "https://go.dev/doc/go1.22"},
+	{"go1.22.3", "https://go.dev/doc/devel/release#go1.22.3"},
+	{"go1.22.3-bigcorp", "https://go.dev/doc/devel/release#go1.22.3"},
+	{"go1.20", "https://go.dev/doc/go1.20"},
+	{"go1.20.5", "https://go.dev/doc/devel/release#go1.20.5"},
+	{"go1.9.2rc2", "https://go.dev/doc/devel/release#go1.9.2"},
+	{"go1", "https://go.dev/doc/go1"},
+	{"go1.0.3", "https://go.dev/doc/devel/release#go1.0.3"},
+	{"1.22.3", ""},
+	{"", ""},
+}
+
+func TestChangelog(t *testing.T) {
+	log, err := Changelog("go1.21.3", "go1.22.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var versions []string
+	for _, e := range log {
+		versions = append(versions, e.Version)
+	}
+	want := slices.Concat(slices.Collect(Releases("go1.21.4", "go1.21.99")), slices.Collect(Releases("go1.22.0", "go1.22.5")))
+	if !slices.Equal(versions, want) {
+		t.Errorf("Changelog(go1.21.3, go1.22.5) versions = %q, want %q", versions, want)
+	}
+
+	e := log[0]
+	if e.Version != "go1.21.4" || e.Date.Format(time.DateOnly) != "2023-11-07" || !e.Security ||
+		!slices.Equal(e.Advisories, Advisories("go1.21.4")) || e.NotesURL != "https://go.dev/doc/devel/release#go1.21.4" {
+		t.Errorf("Changelog(go1.21.3, go1.22.5)[0] = %+v", e)
+	}
+	if i := slices.Index(versions, "go1.22.0"); i >= 0 && (log[i].Security || log[i].NotesURL != "https://go.dev/doc/go1.22") {
+		t.Errorf("Changelog entry for go1.22.0 = %+v", log[i])
+	}
+
+	if log, err := Changelog("go1.22.5", "go1.22.5"); err != nil || len(log) != 0 {
+		t.Errorf("Changelog(go1.22.5, go1.22.5) = %v, %v, want empty", log, err)
+	}
+	for _, tt := range []struct{ from, to string }{
+		{"go1.22.5", "go1.21.3"},
+		{"1.21.3", "go1.22.5"},
+		{"go1.21.3", ""},
+	} {
+		if log, err := Changelog(tt.from, tt.to); err == nil {
+			t.Errorf("Changelog(%q, %q) = %v, nil, want error", tt.from, tt.to, log)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// defaultProxyURL is the Go module proxy.
+const defaultProxyURL = "https://proxy.golang.org"
+
+// defaultSumDBURL is the checksum database.
+const defaultSumDBURL = "https://sum.golang.org"
+
+// sumdbKey is the verifier key of the checksum database sum.golang.org.
+// It is a variable so that tests can replace it.
+var sumdbKey = "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ux18htTTAD8OuAn8"
+
+// proxy returns the base URL of the module proxy, without a trailing slash.
+func (c *Client) proxy() (string, error) {
+	if c.Offline {
+		return "", errors.New("module proxy not available offline")
+	}
+	if c.ProxyURL != "" {
+		return strings.TrimSuffix(c.ProxyURL, "/"), nil
+	}
+	for _, p := range strings.FieldsFunc(os.Getenv("GOPROXY"), func(r rune) bool { return r == ',' || r == '|' }) {
+		switch p = strings.TrimSpace(p); p {
+		case "off":
+			return "", errors.New("module proxy disabled by GOPROXY=off")
+		case "direct", "":
+			// Toolchains are only published through proxies.
+		default:
+			return strings.TrimSuffix(p, "/"), nil
+		}
+	}
+	return defaultProxyURL, nil
+}
+
+// ProxyToolchains returns the names of the toolchains for goos/goarch
+// that the module proxy offers as versions of [ToolchainModule],
+// the way the go command downloads toolchains, in increasing order.
+// The module proxy is often the only source of toolchains reachable
+// from networks that allow access only to a module proxy.
+// Note that a proxy may list only the versions that have been requested
+// from it, rather than every published toolchain.
+func (c *Client) ProxyToolchains(ctx context.Context, goos, goarch string) ([]string, error) {
+	proxy, err := c.proxy()
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.get(ctx, proxy+"/"+ToolchainModule+"/@v/list")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(string(data), "\n") {
+		name, os_, arch, err := ParseToolchainModuleVersion(strings.TrimSpace(line))
+		if err == nil && os_ == goos && arch == goarch {
+			names = append(names, name)
+		}
+	}
+	slices.SortFunc(names, CompareDetail)
+	return slices.Compact(names), nil
+}
+
+// DownloadFromProxy downloads the toolchain name for goos/goarch from the
+// module proxy into the file dst, as a module zip file of [ToolchainModule],
+// and verifies it against the checksum database.
+// Like [Client.Download], it resumes interrupted downloads and, if progress
+// is not nil, reports progress. If the download does not match the checksum
+// database, DownloadFromProxy removes it and returns an error.
+func (c *Client) DownloadFromProxy(ctx context.Context, name, goos, goarch, dst string, progress ProgressFunc) error {
+	version, err := ToolchainModuleVersion(name, goos, goarch)
+	if err != nil {
+		return err
+	}
+	proxy, err := c.proxy()
+	if err != nil {
+		return err
+	}
+	url := proxy + "/" + ToolchainModule + "/@v/" + version + ".zip"
+	return c.fetchFile(ctx, url, dst, progress, func(file string) error {
+		return c.verifyModuleZip(ctx, ToolchainModule, version, file)
+	})
+}
+
+// InstallFromProxy is like [Client.Install] but downloads the toolchain
+// from the module proxy, as by [Client.DownloadFromProxy].
+func (c *Client) InstallFromProxy(ctx context.Context, v, dir string) (string, error) {
+	root, done, err := sdkRoot(v, dir)
+	if err != nil || done {
+		return root, err
+	}
+	name := MustParse(v).Canonical()
+	version, err := ToolchainModuleVersion(name, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Dir(root)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	archive := filepath.Join(dir, version+".zip")
+	if err := c.DownloadFromProxy(ctx, name, runtime.GOOS, runtime.GOARCH, archive, nil); err != nil {
+		return "", err
+	}
+	return unpack(archive, root, ToolchainModule+"@"+version, true)
+}
+
+// verifyModuleZip checks that the module zip file for path@version
+// has the hash recorded in the checksum database.
+func (c *Client) verifyModuleZip(ctx context.Context, path, version, file string) error {
+	hash, err := dirhash.HashZip(file, dirhash.Hash1)
+	if err != nil {
+		return fmt.Errorf("verifying %s@%s: %v", path, version, err)
+	}
+	lines, err := c.sumdbLookup(ctx, path, version)
+	if err != nil {
+		return fmt.Errorf("verifying %s@%s: %v", path, version, err)
+	}
+	want := path + " " + version + " "
+	for _, line := range lines {
+		if h, ok := strings.CutPrefix(line, want); ok {
+			if h != hash {
+				return fmt.Errorf("verifying %s@%s: checksum mismatch: have %s, want %s", path, version, hash, h)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("verifying %s@%s: not found in checksum database", path, version)
+}
+
+// sumdbLookup returns the go.sum lines for path@version
+// recorded in the checksum database.
+func (c *Client) sumdbLookup(ctx context.Context, path, version string) ([]string, error) {
+	base := c.SumDBURL
+	if base == "" {
+		proxy, err := c.proxy()
+		if err != nil {
+			return nil, err
+		}
+		// Like the go command, prefer to reach the database through the proxy.
+		name, _, _ := strings.Cut(sumdbKey, "+")
+		base = defaultSumDBURL
+		if _, err := c.get(ctx, proxy+"/sumdb/"+name+"/supported"); err == nil {
+			base = proxy + "/sumdb/" + name
+		}
+	}
+	ops := &sumdbOps{c: c, ctx: ctx, base: strings.TrimSuffix(base, "/")}
+	return sumdb.NewClient(ops).Lookup(path, version)
+}
+
+// sumdbOps implements sumdb.ClientOps, keeping the database configuration
+// and cache in memory in the Client.
+type sumdbOps struct {
+	c    *Client
+	ctx  context.Context
+	base string
+}
+
+func (o *sumdbOps) ReadRemote(path string) ([]byte, error) {
+	return o.c.get(o.ctx, o.base+path)
+}
+
+func (o *sumdbOps) ReadConfig(file string) ([]byte, error) {
+	if file == "key" {
+		return []byte(sumdbKey), nil
+	}
+	o.c.sumdbMu.Lock()
+	defer o.c.sumdbMu.Unlock()
+	return o.c.sumdbConfig[file], nil // nil for an initial empty tree
+}
+
+func (o *sumdbOps) WriteConfig(file string, old, new []byte) error {
+	o.c.sumdbMu.Lock()
+	defer o.c.sumdbMu.Unlock()
+	if !bytes.Equal(o.c.sumdbConfig[file], old) {
+		return sumdb.ErrWriteConflict
+	}
+	if o.c.sumdbConfig == nil {
+		o.c.sumdbConfig = make(map[string][]byte)
+	}
+	o.c.sumdbConfig[file] = new
+	return nil
+}
+
+func (o *sumdbOps) ReadCache(file string) ([]byte, error) {
+	o.c.sumdbMu.Lock()
+	defer o.c.sumdbMu.Unlock()
+	if data, ok := o.c.sumdbCache[file]; ok {
+		return data, nil
+	}
+	return nil, fs.ErrNotExist
+}
+
+func (o *sumdbOps) WriteCache(file string, data []byte) {
+	o.c.sumdbMu.Lock()
+	defer o.c.sumdbMu.Unlock()
+	if o.c.sumdbCache == nil {
+		o.c.sumdbCache = make(map[string][]byte)
+	}
+	o.c.sumdbCache[file] = data
+}
+
+func (o *sumdbOps) Log(msg string) {}
+
+// SecurityError is called when the database misbehaves;
+// the lookup that detected it fails with an error.
+func (o *sumdbOps) SecurityError(msg string) {}
+
+// get returns the body of the response to a GET request for url.
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %v", url, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,189 @@
+package gover
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/dirhash"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// toolchainZip returns a module zip file of the toolchain module version,
+// holding the given files.
+func toolchainZip(t *testing.T, version string, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range files {
+		w, err := zw.Create(ToolchainModule + "@" + version + "/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(data))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// newProxyClient returns a client whose module proxy serves
+// go1.22.1 for the current system, along with a checksum database
+// recording the hash of the zip file good, while the proxy serves
+// the zip file served.
+func newProxyClient(t *testing.T, good, served []byte) *Client {
+	t.Helper()
+	version, err := ToolchainModuleVersion("go1.22.1", runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "good.zip")
+	if err := os.WriteFile(file, good, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := dirhash.HashZip(file, dirhash.Hash1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	skey, vkey, err := note.GenerateKey(rand.Reader, "sum.golang.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := sumdbKey
+	sumdbKey = vkey
+	t.Cleanup(func() { sumdbKey = saved })
+	db := sumdb.NewServer(sumdb.NewTestServer(skey, func(path, vers string) ([]byte, error) {
+		if path != ToolchainModule || vers != version {
+			return nil, os.ErrNotExist
+		}
+		return []byte(path + " " + vers + " " + hash + "\n" + path + " " + vers + "/go.mod h1:fake=\n"), nil
+	}))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/"+ToolchainModule+"/@v/list", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Join([]string{
+			version,
+			"v0.0.1-go1.21rc2." + runtime.GOOS + "-" + runtime.GOARCH,
+			"v0.0.1-go1.22.1.plan9-mips",
+			"v0.0.1-go1.23.0.plan9-mips",
+			"v0.0.1-bogus",
+			"",
+		}, "\n")))
+	})
+	mux.HandleFunc("/"+ToolchainModule+"/@v/"+version+".zip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(served)
+	})
+	mux.HandleFunc("/sumdb/sum.golang.org/supported", func(w http.ResponseWriter, r *http.Request) {})
+	for _, path := range sumdb.ServerPaths {
+		mux.Handle("/sumdb/sum.golang.org"+path, http.StripPrefix("/sumdb/sum.golang.org", db))
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return &Client{CacheDir: "off", ProxyURL: srv.URL}
+}
+
+var proxyToolchainFiles = map[string]string{
+	"VERSION": "go1.22.1\ntime 2024-03-05T22:33:05Z\n",
+	"bin/go":  "#!/bin/sh\n",
+	"pkg/tool/" + runtime.GOOS + "_" + runtime.GOARCH + "/compile": "#!/bin/sh\n",
+	"src/fmt/print.go": "package fmt\n",
+}
+
+func TestProxyToolchains(t *testing.T) {
+	data := toolchainZip(t, "v0.0.1-go1.22.1."+runtime.GOOS+"-"+runtime.GOARCH, proxyToolchainFiles)
+	c := newProxyClient(t, data, data)
+	names, err := c.ProxyToolchains(context.Background(), runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"go1.21rc2", "go1.22.1"}; strings.Join(names, " ") != strings.Join(want, " ") {
+		t.Errorf("ProxyToolchains() = %v, want %v", names, want)
+	}
+}
+
+func TestInstallFromProxy(t *testing.T) {
+	data := toolchainZip(t, "v0.0.1-go1.22.1."+runtime.GOOS+"-"+runtime.GOARCH, proxyToolchainFiles)
+	c := newProxyClient(t, data, data)
+	dir := t.TempDir()
+	root, err := c.InstallFromProxy(context.Background(), "go1.22.1", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "go1.22.1"); root != want {
+		t.Errorf("InstallFromProxy() = %q, want %q", root, want)
+	}
+	if v, _, err := GorootVersion(root); err != nil || v.String() != "go1.22.1" {
+		t.Errorf("GorootVersion(installed root) = %v, %v", v, err)
+	}
+	if runtime.GOOS != "windows" {
+		for _, name := range []string{"bin/go", "pkg/tool/" + runtime.GOOS + "_" + runtime.GOARCH + "/compile"} {
+			if info, err := os.Stat(filepath.Join(root, name)); err != nil || info.Mode()&0o100 == 0 {
+				t.Errorf("%s not executable: %v", name, err)
+			}
+		}
+		if info, err := os.Stat(filepath.Join(root, "src/fmt/print.go")); err != nil || info.Mode()&0o111 != 0 {
+			t.Errorf("src/fmt/print.go executable: %v", err)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("InstallFromProxy left %d entries in %s, want 1", len(entries), dir)
+	}
+}
+
+func TestDownloadFromProxyMismatch(t *testing.T) {
+	version := "v0.0.1-go1.22.1." + runtime.GOOS + "-" + runtime.GOARCH
+	good := toolchainZip(t, version, proxyToolchainFiles)
+	evil := toolchainZip(t, version, map[string]string{"VERSION": "go1.22.1\n", "bin/go": "#!/bin/sh\nrm -rf /\n"})
+	c := newProxyClient(t, good, evil)
+	dst := filepath.Join(t.TempDir(), "go.zip")
+	err := c.DownloadFromProxy(context.Background(), "go1.22.1", runtime.GOOS, runtime.GOARCH, dst, nil)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("DownloadFromProxy of tampered zip: %v, want checksum mismatch", err)
+	}
+	for _, file := range []string{dst, dst + ".partial"} {
+		if _, err := os.Stat(file); err == nil {
+			t.Errorf("DownloadFromProxy of tampered zip left %s", file)
+		}
+	}
+	if err := c.DownloadFromProxy(context.Background(), "go1.21.0", runtime.GOOS, runtime.GOARCH, dst, nil); err == nil {
+		t.Errorf("DownloadFromProxy of missing toolchain succeeded")
+	}
+}
+
+func TestClientProxy(t *testing.T) {
+	for _, tt := range []struct {
+		goproxy, out string
+	}{
+		{"", "https://proxy.golang.org"},
+		{"direct", "https://proxy.golang.org"},
+		{"https://goproxy.example/,direct", "https://goproxy.example"},
+		{"direct|https://a.example|https://b.example", "https://a.example"},
+		{"off", ""},
+		{"direct,off", ""},
+	} {
+		t.Setenv("GOPROXY", tt.goproxy)
+		out, err := new(Client).proxy()
+		if out != tt.out || (err != nil) != (tt.out == "") {
+			t.Errorf("GOPROXY=%s: proxy() = %q, %v, want %q", tt.goproxy, out, err, tt.out)
+		}
+	}
+	c := &Client{ProxyURL: "https://corp.example/goproxy/", Offline: true}
+	if _, err := c.proxy(); err == nil {
+		t.Errorf("offline proxy() succeeded")
+	}
+	c.Offline = false
+	if out, err := c.proxy(); out != "https://corp.example/goproxy" || err != nil {
+		t.Errorf("proxy() = %q, %v, want https://corp.example/goproxy", out, err)
+	}
+}
@@ -0,0 +1,64 @@
+package gover
+
+// A Range is an interval of Go versions, such as the versions
+// from go1.21.0 up to but not including go1.23.
+// A zero Lo or Hi leaves that end of the range unbounded,
+// in which case the corresponding IncLo or IncHi is ignored.
+//
+// Lo and Hi need not be valid versions: a Version with only a major version
+// or only major and minor versions set, such as {Major: "1", Minor: "22"},
+// compares less than every version in that series, including prereleases,
+// so Range{Lo: New(1, 21, 0), IncLo: true, Hi: Version{Major: "1", Minor: "22"}}
+// holds exactly the go1.21 releases.
+type Range struct {
+	Lo, Hi       Version
+	IncLo, IncHi bool // whether Lo and Hi are themselves in the range
+}
+
+// interval returns r as an interval, clearing the inclusion of unbounded ends.
+func (r Range) interval() interval {
+	lo, hi := bound{r.Lo, r.IncLo}, bound{r.Hi, r.IncHi}
+	if lo.v == (Version{}) {
+		lo.inc = false
+	}
+	if hi.v == (Version{}) {
+		hi.inc = false
+	}
+	return interval{lo, hi}
+}
+
+// Contains reports whether v lies in r.
+// Versions are compared using Compare.
+func (r Range) Contains(v Version) bool {
+	return v != (Version{}) && r.interval().contains(v)
+}
+
+// Overlaps reports whether some version lies in both r and s.
+func (r Range) Overlaps(s Range) bool {
+	return !r.interval().intersect(s.interval()).empty()
+}
+
+// Merge returns the smallest range containing both r and s
+// if the two ranges overlap or are adjacent, so that together they
+// form a single range. Otherwise, Merge returns the zero Range and false.
+// For example, [go1.21, go1.22) and [go1.22, go1.23) merge to [go1.21, go1.23),
+// but [go1.21, go1.22) and (go1.22, go1.23) do not merge because
+// neither contains go1.22. Merging an empty range with another range
+// returns the other range.
+func (r Range) Merge(s Range) (Range, bool) {
+	x, y := r.interval(), s.interval()
+	switch {
+	case x.empty():
+		return s, true
+	case y.empty():
+		return r, true
+	}
+	if cmpLo(x.lo, y.lo) > 0 {
+		x, y = y, x
+	}
+	if !touches(x.hi, y.lo) {
+		return Range{}, false
+	}
+	hi := maxHi(x.hi, y.hi)
+	return Range{Lo: x.lo.v, IncLo: x.lo.inc, Hi: hi.v, IncHi: hi.inc}, true
+}
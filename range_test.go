@@ -0,0 +1,78 @@
+package gover
+
+import "testing"
+
+var (
+	rangeGo121 = Range{Lo: Version{Major: "1", Minor: "21"}, IncLo: true, Hi: Version{Major: "1", Minor: "22"}} // [go1.21, go1.22)
+	rangeGo122 = Range{Lo: Version{Major: "1", Minor: "22"}, IncLo: true, Hi: Version{Major: "1", Minor: "23"}} // [go1.22, go1.23)
+	rangeAfter = Range{Lo: MustParse("go1.22"), IncLo: false}                                                   // (go1.22, ∞)
+	rangeUpTo  = Range{Hi: MustParse("go1.21.3"), IncHi: true}                                                  // (-∞, go1.21.3]
+	rangeEmpty = Range{Lo: MustParse("go1.22"), Hi: MustParse("go1.21")}                                        // empty
+)
+
+var rangeContainsTests = []struct {
+	r   Range
+	v   string
+	out bool
+}{
+	{rangeGo121, "go1.21rc1", true},
+	{rangeGo121, "go1.21", true},
+	{rangeGo121, "go1.21.9", true},
+	{rangeGo121, "go1.20.9", false},
+	{rangeGo121, "go1.22rc1", false},
+	{rangeAfter, "go1.22", false},
+	{rangeAfter, "go1.22rc1", true},
+	{rangeAfter, "go2", true},
+	{rangeUpTo, "go1", true},
+	{rangeUpTo, "go1.21.3", true},
+	{rangeUpTo, "go1.21.4", false},
+	{Range{}, "go1.21.0", true},
+	{Range{IncLo: true, IncHi: true}, "go1.21.0", true},
+	{rangeEmpty, "go1.21.5", false},
+}
+
+func TestRangeContains(t *testing.T) {
+	for _, tt := range rangeContainsTests {
+		if out := tt.r.Contains(MustParse(tt.v)); out != tt.out {
+			t.Errorf("%v.Contains(%q) = %v, want %v", tt.r, tt.v, out, tt.out)
+		}
+	}
+	if rangeGo121.Contains(Version{}) || (Range{}).Contains(Version{}) {
+		t.Errorf("Contains(Version{}) = true, want false")
+	}
+}
+
+var rangeMergeTests = []struct {
+	r, s    Range
+	overlap bool
+	merge   Range
+	ok      bool
+}{
+	{rangeGo121, rangeGo122, false, Range{Lo: Version{Major: "1", Minor: "21"}, IncLo: true, Hi: Version{Major: "1", Minor: "23"}}, true},
+	{rangeGo122, rangeGo121, false, Range{Lo: Version{Major: "1", Minor: "21"}, IncLo: true, Hi: Version{Major: "1", Minor: "23"}}, true},
+	{rangeGo121, rangeAfter, false, Range{}, false},
+	{rangeGo122, rangeAfter, true, Range{Lo: Version{Major: "1", Minor: "22"}, IncLo: true}, true},
+	{rangeGo121, rangeUpTo, true, Range{Hi: Version{Major: "1", Minor: "22"}}, true},
+	{rangeUpTo, rangeAfter, false, Range{}, false},
+	{
+		Range{Hi: MustParse("go1.22"), IncHi: true}, rangeAfter, false,
+		Range{}, true,
+	},
+	{
+		Range{Lo: MustParse("go1.21.1"), Hi: MustParse("go1.21.1"), IncLo: true, IncHi: true}, rangeGo121, true,
+		rangeGo121, true,
+	},
+	{rangeEmpty, rangeGo121, false, rangeGo121, true},
+	{rangeGo121, rangeEmpty, false, rangeGo121, true},
+}
+
+func TestRangeMerge(t *testing.T) {
+	for _, tt := range rangeMergeTests {
+		if overlap := tt.r.Overlaps(tt.s); overlap != tt.overlap {
+			t.Errorf("%v.Overlaps(%v) = %v, want %v", tt.r, tt.s, overlap, tt.overlap)
+		}
+		if merge, ok := tt.r.Merge(tt.s); merge != tt.merge || ok != tt.ok {
+			t.Errorf("%v.Merge(%v) = %v, %v, want %v, %v", tt.r, tt.s, merge, ok, tt.merge, tt.ok)
+		}
+	}
+}
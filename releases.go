@@ -0,0 +1,180 @@
+package gover
+
+import (
+	_ "embed"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:generate go run gen_releases.go
+
+// releasesTxt is the table of Go releases, one per line,
+// giving the version, the release date or "-" if unknown,
+// and "stable" for releases or "unstable" for prereleases,
+// which gen_releases.go omits.
+// Lines beginning with # are comments.
+//
+//go:embed releases.txt
+var releasesTxt string
+
+// securityTxt lists the security releases, one per line,
+// giving the version followed by the advisories it fixes.
+// Lines beginning with # are comments.
+//
+//go:embed security.txt
+var securityTxt string
+
+// A release is an entry in the release table.
+type release struct {
+	version string
+	date    time.Time // zero if unknown
+	stable  bool
+
+	advisories []string // advisories fixed, if a security release
+}
+
+// releaseTable returns the parsed release table, in increasing version order.
+var releaseTable = sync.OnceValue(func() []release {
+	var table []release
+	for _, line := range strings.Split(releasesTxt, "\n") {
+		f := strings.Fields(line)
+		if len(f) == 0 || strings.HasPrefix(f[0], "#") {
+			continue
+		}
+		if len(f) != 3 || !IsValid(f[0]) {
+			panic("gover: malformed release table line: " + line)
+		}
+		r := release{version: f[0], stable: f[2] == "stable"}
+		if f[1] != "-" {
+			d, err := time.Parse(time.DateOnly, f[1])
+			if err != nil {
+				panic("gover: malformed release table line: " + line)
+			}
+			r.date = d
+		}
+		table = append(table, r)
+	}
+	slices.SortStableFunc(table, func(x, y release) int { return Compare(x.version, y.version) })
+
+	for _, line := range strings.Split(securityTxt, "\n") {
+		f := strings.Fields(line)
+		if len(f) == 0 || strings.HasPrefix(f[0], "#") {
+			continue
+		}
+		i, ok := slices.BinarySearchFunc(table, f[0], func(r release, v string) int { return Compare(r.version, v) })
+		if len(f) < 2 || !ok {
+			panic("gover: malformed security table line: " + line)
+		}
+		table[i].advisories = f[1:]
+	}
+	return table
+})
+
+// lookupRelease returns the release table entry for the version v.
+func lookupRelease(v string) (release, bool) {
+	if !IsValid(v) {
+		return release{}, false
+	}
+	table := releaseTable()
+	i, ok := slices.BinarySearchFunc(table, v, func(r release, v string) int { return Compare(r.version, v) })
+	if !ok {
+		return release{}, false
+	}
+	return table[i], true
+}
+
+// IsKnown reports whether the version v is a Go release
+// listed in the release table embedded in the package, which includes
+// the versions published up to the time the package was last updated.
+// For example, IsKnown("go1.21.7") is true, but IsKnown("go1.21") is false,
+// because go1.21 is a language version that was never released on its own,
+// and IsKnown("go1.7.2") is false, because that version was tagged but not released.
+// Prereleases, such as go1.21rc2, are not in the table and so are not known.
+// Versions that compare equal to a known version, such as "go1.20.0"
+// for "go1.20", are also reported as known.
+func IsKnown(v string) bool {
+	_, ok := lookupRelease(v)
+	return ok
+}
+
+// ReleaseDate returns the date on which the version v was released,
+// according to the release table embedded in the package.
+// For a language version such as "go1.21", which is never released on its own,
+// ReleaseDate returns the date of its first release, go1.21.0.
+// If v is not a known version, or its release date is not recorded,
+// as for prereleases, ReleaseDate returns the zero time and false.
+func ReleaseDate(v string) (time.Time, bool) {
+	r, ok := lookupRelease(v)
+	if !ok && IsLang(v) {
+		r, ok = lookupRelease(NextPatch(v))
+	}
+	if !ok || r.date.IsZero() {
+		return time.Time{}, false
+	}
+	return r.date, true
+}
+
+// IsSecurityRelease reports whether the version v is a release
+// that fixed security vulnerabilities, according to the table of
+// security releases embedded in the package, which covers releases
+// from go1.19 on.
+func IsSecurityRelease(v string) bool {
+	r, _ := lookupRelease(v)
+	return len(r.advisories) > 0
+}
+
+// Advisories returns the identifiers of the security advisories,
+// such as "CVE-2023-39325", fixed by the release v, as listed in the
+// table of security releases embedded in the package.
+// It returns nil if v is not a security release.
+func Advisories(v string) []string {
+	r, _ := lookupRelease(v)
+	return slices.Clone(r.advisories)
+}
+
+// Releases returns an iterator over the Go releases from the version from
+// through the version to, inclusive, in increasing order.
+// Only releases are included, not prereleases.
+// An empty from or to leaves the range unbounded on that side,
+// so Releases("go1.21", "") yields every release from go1.21.0 onward.
+// If from or to is neither empty nor valid, the iterator yields nothing.
+//
+// The releases come from a table embedded in the package,
+// which lists the releases up to the time the package was last updated.
+func Releases(from, to string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if from != "" && !IsValid(from) || to != "" && !IsValid(to) {
+			return
+		}
+		for _, r := range releaseTable() {
+			if r.stable && Between(r.version, from, to) && !yield(r.version) {
+				return
+			}
+		}
+	}
+}
+
+// Langs returns an iterator over the Go language versions from that of
+// the version from through that of the version to, inclusive,
+// in increasing order. For example, Langs("go1.18", "go1.21.3") yields
+// go1.18, go1.19, go1.20, and go1.21.
+// Unlike Releases, Langs does not consult the release table,
+// so it can yield language versions that have not yet been released.
+// If from or to is invalid, or they have different major versions,
+// the iterator yields nothing.
+func Langs(from, to string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		lo, hi := parse(stripGo(from)), parse(stripGo(to))
+		if lo == (Version{}) || hi == (Version{}) || lo.Major != hi.Major {
+			return
+		}
+		for minor := lo.Minor; CmpInt(minor, hi.Minor) <= 0; minor = IncInt(minor) {
+			if !yield(Version{Major: lo.Major, Minor: minor}.Lang().Canonical()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package gover
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+var releasesTests = []struct {
+	from, to string
+	out      []string
+}{
+	{"go1.21rc1", "go1.21.3", []string{"go1.21.0", "go1.21.1", "go1.21.2", "go1.21.3"}},
+	{"go1.20.13", "go1.21.0", []string{"go1.20.13", "go1.20.14", "go1.21.0"}},
+	{"go1", "go1.1", []string{"go1", "go1.0.1", "go1.0.2", "go1.0.3", "go1.1"}},
+	{"go1.7.1", "go1.7.4", []string{"go1.7.1", "go1.7.3", "go1.7.4"}},
+	{"", "go1.0.2", []string{"go1", "go1.0.1", "go1.0.2"}},
+	{"go1.21.99", "go1.22", nil},
+	{"go1.22.0", "go1.21.0", nil},
+	{"bad", "go1.22.0", nil},
+	{"go1.21.0", "bad", nil},
+}
+
+func TestReleases(t *testing.T) {
+	for _, tt := range releasesTests {
+		if out := slices.Collect(Releases(tt.from, tt.to)); !slices.Equal(out, tt.out) {
+			t.Errorf("Releases(%q, %q) = %q, want %q", tt.from, tt.to, out, tt.out)
+		}
+	}
+
+	all := slices.Collect(Releases("", ""))
+	for i, r := range all {
+		if !IsRelease(r) {
+			t.Errorf("Releases yielded non-release %q", r)
+		}
+		if i > 0 && Compare(all[i-1], r) >= 0 {
+			t.Errorf("Releases yielded %q before %q", all[i-1], r)
+		}
+	}
+
+	// Stopping early.
+	var got []string
+	for r := range Releases("go1.22.0", "") {
+		got = append(got, r)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if want := []string{"go1.22.0", "go1.22.1"}; !slices.Equal(got, want) {
+		t.Errorf("first two of Releases(go1.22.0, \"\") = %q, want %q", got, want)
+	}
+}
+
+var langsTests = []struct {
+	from, to string
+	out      []string
+}{
+	{"go1.18", "go1.21.3", []string{"go1.18", "go1.19", "go1.20", "go1.21"}},
+	{"go1.20.5", "go1.20rc1", []string{"go1.20"}},
+	{"go1", "go1.2", []string{"go1", "go1.1", "go1.2"}},
+	{"go1.0.3", "go1.1rc2", []string{"go1", "go1.1"}},
+	{"go1.29", "go1.31", []string{"go1.29", "go1.30", "go1.31"}},
+	{"go1.22", "go1.21", nil},
+	{"go1.21", "go2", nil},
+	{"bad", "go1.21", nil},
+	{"go1.21", "", nil},
+}
+
+func TestLangs(t *testing.T) {
+	for _, tt := range langsTests {
+		if out := slices.Collect(Langs(tt.from, tt.to)); !slices.Equal(out, tt.out) {
+			t.Errorf("Langs(%q, %q) = %q, want %q", tt.from, tt.to, out, tt.out)
+		}
+	}
+	for v := range Langs("go1.9", "go1.12") {
+		if v == "go1.10" {
+			break
+		}
+		if v != "go1.9" {
+			t.Errorf("Langs continued after break: %q", v)
+		}
+	}
+}
+
+func TestReleaseTable(t *testing.T) {
+	table := releaseTable()
+	if len(table) == 0 {
+		t.Fatal("empty release table")
+	}
+	for i, r := range table {
+		if r.stable != IsRelease(r.version) {
+			t.Errorf("release table: %s marked stable=%v", r.version, r.stable)
+		}
+		// gen_releases.go omits prereleases.
+		if !IsRelease(r.version) {
+			t.Errorf("release table: prerelease %s listed", r.version)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := table[i-1]
+		if Compare(prev.version, r.version) >= 0 {
+			t.Errorf("release table: %s listed after %s", r.version, prev.version)
+		}
+		// Within a series, later releases are not dated earlier.
+		if Lang(prev.version) == Lang(r.version) && !prev.date.IsZero() && !r.date.IsZero() && r.date.Before(prev.date) {
+			t.Errorf("release table: %s dated %v, before %s dated %v", r.version, r.date, prev.version, prev.date)
+		}
+	}
+}
+
+var isKnownTests = []struct {
+	v   string
+	out bool
+}{
+	{"go1", true},
+	{"go1.0.0", true},
+	{"go1.20", true},
+	{"go1.20.0", true},
+	{"go1.21.7", true},
+	{"go1.21", false},
+	{"go1.21.0", true},
+	{"go1.7.2", false},
+	{"go1.21rc2", false},
+	{"go1.21.99", false},
+	{"1.21.0", false},
+	{"bad", false},
+}
+
+func TestIsKnown(t *testing.T) {
+	for _, tt := range isKnownTests {
+		if out := IsKnown(tt.v); out != tt.out {
+			t.Errorf("IsKnown(%q) = %v, want %v", tt.v, out, tt.out)
+		}
+	}
+}
+
+var releaseDateTests = []struct {
+	v    string
+	date string
+}{
+	{"go1", "2012-03-28"},
+	{"go1.21.0", "2023-08-08"},
+	{"go1.21", "2023-08-08"},
+	{"go1.20", "2023-02-01"},
+	{"go1.20.0", "2023-02-01"},
+	{"go1.21.7", "2024-02-06"},
+	{"go1.21.99", ""},
+	{"go1.7.2", ""},
+	{"go1.99", ""},
+	{"bad", ""},
+}
+
+func TestReleaseDate(t *testing.T) {
+	for _, tt := range releaseDateTests {
+		date, ok := ReleaseDate(tt.v)
+		if got := date.Format(time.DateOnly); !ok && tt.date != "" || ok && got != tt.date || !ok && !date.IsZero() {
+			t.Errorf("ReleaseDate(%q) = %v, %v, want %q", tt.v, date, ok, tt.date)
+		}
+	}
+}
+
+var advisoriesTests = []struct {
+	v   string
+	out []string
+}{
+	{"go1.21.3", []string{"CVE-2023-39325"}},
+	{"go1.20.10", []string{"CVE-2023-39325"}},
+	{"go1.22.4", []string{"CVE-2024-24789", "CVE-2024-24790"}},
+	{"go1.21.0", nil},
+	{"go1.21.6", nil},
+	{"go1.99.1", nil},
+	{"bad", nil},
+}
+
+func TestAdvisories(t *testing.T) {
+	for _, tt := range advisoriesTests {
+		if out := Advisories(tt.v); !slices.Equal(out, tt.out) {
+			t.Errorf("Advisories(%q) = %q, want %q", tt.v, out, tt.out)
+		}
+		if out := IsSecurityRelease(tt.v); out != (tt.out != nil) {
+			t.Errorf("IsSecurityRelease(%q) = %v, want %v", tt.v, out, tt.out != nil)
+		}
+	}
+
+	// Security releases are releases.
+	for _, r := range releaseTable() {
+		if r.advisories != nil && !r.stable {
+			t.Errorf("prerelease %s listed as a security release", r.version)
+		}
+	}
+
+	// The result is a copy.
+	a := Advisories("go1.21.3")
+	a[0] = "changed"
+	if Advisories("go1.21.3")[0] != "CVE-2023-39325" {
+		t.Errorf("modifying Advisories result changed the table")
+	}
+}
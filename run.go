@@ -0,0 +1,89 @@
+package gover
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Run runs the command args under Go version v, as by [Client.Run]
+// with the zero Client.
+func Run(ctx context.Context, v string, args ...string) error {
+	return new(Client).Run(ctx, v, args...)
+}
+
+// Run runs the command args under Go version v, such as
+//
+//	c.Run(ctx, "go1.21.13", "go", "test", "./...")
+//
+// connected to the standard input, output, and error of the current process.
+// See [Client.Command] for how the toolchain is found and the command run.
+func (c *Client) Run(ctx context.Context, v string, args ...string) error {
+	cmd, err := c.Command(ctx, v, args...)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
+// Command returns a command that runs args under Go version v,
+// installing the toolchain first if necessary.
+//
+// Command uses a toolchain of version v among those listed by
+// [InstalledSDKs], or else installs one with [Client.Install].
+// The command runs with GOROOT set to the toolchain's root, the
+// toolchain's bin directory first in PATH, and GOTOOLCHAIN=local,
+// so that the go command does not switch to another toolchain.
+// If args[0] names a command in the bin directory, such as "go" or
+// "gofmt", that command is run; otherwise args[0] is looked up as usual.
+func (c *Client) Command(ctx context.Context, v string, args ...string) (*exec.Cmd, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("running under %s: missing command", v)
+	}
+	root, err := c.toolchainRoot(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	bin := filepath.Join(root, "bin")
+	name := args[0]
+	if !strings.ContainsAny(name, `/\`) {
+		file := filepath.Join(bin, name)
+		if runtime.GOOS == "windows" && !strings.HasSuffix(strings.ToLower(name), ".exe") {
+			file += ".exe"
+		}
+		if isExecutable(file) {
+			name = file
+		}
+	}
+	cmd := exec.CommandContext(ctx, name, args[1:]...)
+	cmd.Env = append(os.Environ(),
+		"GOROOT="+root,
+		"PATH="+bin+string(filepath.ListSeparator)+os.Getenv("PATH"),
+		"GOTOOLCHAIN=local",
+	)
+	return cmd, nil
+}
+
+// toolchainRoot returns the root of an installed toolchain of version v,
+// installing one if there is none.
+func (c *Client) toolchainRoot(ctx context.Context, v string) (string, error) {
+	want, err := Parse(v)
+	if err != nil {
+		return "", err
+	}
+	sdks, err := InstalledSDKs()
+	if err != nil {
+		return "", err
+	}
+	for _, sdk := range sdks {
+		if sdk.Version.Equal(want) {
+			return sdk.Root, nil
+		}
+	}
+	return c.Install(ctx, v, "")
+}
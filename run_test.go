@@ -0,0 +1,58 @@
+package gover
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses a shell script")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	root := writeSDK(t, filepath.Join(home, "sdk"), "go1.21.13", "go1.21.13\n")
+	os.Mkdir(filepath.Join(root, "bin"), 0o777)
+	script := "#!/bin/sh\necho \"$GOROOT $GOTOOLCHAIN $*\"\n"
+	if err := os.WriteFile(filepath.Join(root, "bin", "go"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Client{Offline: true}
+	ctx := context.Background()
+	cmd, err := c.Command(ctx, "go1.21.13", "go", "test", "./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.TrimSpace(string(out)), root+" local test ./..."; got != want {
+		t.Errorf("go output = %q, want %q", got, want)
+	}
+
+	// Commands not in the toolchain are found in PATH, after the toolchain.
+	cmd, err = c.Command(ctx, "go1.21.13", "sh", "-c", "echo $PATH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err = cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(out); !strings.HasPrefix(got, filepath.Join(root, "bin")+string(filepath.ListSeparator)) {
+		t.Errorf("PATH = %q, want toolchain bin directory first", got)
+	}
+
+	if _, err := c.Command(ctx, "go1.21.13"); err == nil {
+		t.Errorf("Command with no arguments succeeded")
+	}
+	if _, err := c.Command(ctx, "go1.22.6", "go", "version"); err == nil {
+		t.Errorf("offline Command of uninstalled version succeeded")
+	}
+}
@@ -0,0 +1,83 @@
+package gover
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// runtimeVersion returns the version of the running Go toolchain.
+// It is a variable so that tests can replace it.
+var runtimeVersion = runtime.Version
+
+// ParseRuntime parses a version as reported by [runtime.Version].
+// In addition to the versions accepted by Parse, such as "go1.22.1",
+// ParseRuntime accepts development versions such as
+//
+//	devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000
+//
+// returning the language version under development, here go1.23,
+// with Devel set and Commit holding the commit hash "2b1f1e8".
+// Old development versions that do not record a Go version,
+// such as "devel +2b1f1e8 Thu Jan 4 20:00:00 2024 +0000", are rejected.
+//
+// Toolchains built with a non-default GOEXPERIMENT setting report it after
+// the version, as in "go1.21.0 X:loopvar,rangefunc"; ParseRuntime records
+// the list of experiments ("loopvar,rangefunc") in the Experiment field.
+func ParseRuntime(x string) (Version, error) {
+	s, experiment, _ := strings.Cut(x, " X:")
+	v, err := parseRuntime(x, s)
+	if err != nil {
+		return Version{}, err
+	}
+	v.Experiment = experiment
+	return v, nil
+}
+
+// parseRuntime parses the runtime version s, which is a prefix of x.
+func parseRuntime(x, s string) (Version, error) {
+	rest, ok := strings.CutPrefix(s, "devel ")
+	if !ok {
+		v, _, err := parseFull(s)
+		if err != nil {
+			err.Input = x
+			return Version{}, err
+		}
+		return v, nil
+	}
+	f := strings.Fields(rest)
+	if len(f) == 0 || !strings.HasPrefix(f[0], "go") {
+		return Version{}, fmt.Errorf("development version %q does not record a Go version", x)
+	}
+	v, _, err := parseFull(f[0])
+	if err != nil {
+		err.Input = x
+		err.Offset += strings.Index(x, f[0])
+		return Version{}, err
+	}
+	v.Devel = true
+	v.Commit, v.Suffix = v.Suffix, ""
+	return v, nil
+}
+
+// AssertRuntime returns an error unless the running Go toolchain,
+// as reported by [runtime.Version], compares equal to expected.
+// Development toolchains, whose versions begin with "devel",
+// never match and produce an error saying so.
+func AssertRuntime(expected string) error {
+	if !IsValid(expected) {
+		return fmt.Errorf("invalid version %q", expected)
+	}
+	running := runtimeVersion()
+	if strings.HasPrefix(running, "devel") {
+		return fmt.Errorf("running development toolchain %q but %s is required", running, expected)
+	}
+	v, err := ParseRuntime(running)
+	if err != nil {
+		return fmt.Errorf("running toolchain with invalid version %q but %s is required", running, expected)
+	}
+	if v.Compare(MustParse(expected)) != 0 {
+		return fmt.Errorf("running %s but %s is required", running, expected)
+	}
+	return nil
+}
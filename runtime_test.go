@@ -0,0 +1,99 @@
+package gover
+
+import (
+	"reflect"
+	"testing"
+)
+
+var assertRuntimeTests = []struct {
+	running  string
+	expected string
+	err      string
+}{
+	{"go1.21.4", "go1.21.4", ""},
+	{"go1.20", "go1.20.0", ""},
+	{"go1.22.0", "go1.21.4", "running go1.22.0 but go1.21.4 is required"},
+	{"go1.21.4", "go1.21", "running go1.21.4 but go1.21 is required"},
+	{"devel go1.23-2b1f1e8 Thu Jan 4 12:00:00 2024 +0000", "go1.23.0", `running development toolchain "devel go1.23-2b1f1e8 Thu Jan 4 12:00:00 2024 +0000" but go1.23.0 is required`},
+	{"go1.21.4 X:loopvar", "go1.21.4", ""},
+	{"devel +2b1f1e8 Thu Jan 4 12:00:00 2024 +0000", "go1.23.0", `running development toolchain "devel +2b1f1e8 Thu Jan 4 12:00:00 2024 +0000" but go1.23.0 is required`},
+	{"weird", "go1.21.4", `running toolchain with invalid version "weird" but go1.21.4 is required`},
+	{"go1.21.4", "1.21.4", `invalid version "1.21.4"`},
+}
+
+func TestAssertRuntime(t *testing.T) {
+	defer func(f func() string) { runtimeVersion = f }(runtimeVersion)
+	for _, tt := range assertRuntimeTests {
+		runtimeVersion = func() string { return tt.running }
+		err := AssertRuntime(tt.expected)
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || err.Error() != tt.err) {
+			t.Errorf("with runtime %q: AssertRuntime(%q) = %v, want %q", tt.running, tt.expected, err, tt.err)
+		}
+	}
+}
+
+var parseRuntimeTests = []struct {
+	in  string
+	out Version
+	err bool
+}{
+	{"go1.22.1", Version{Major: "1", Minor: "22", Patch: "1"}, false},
+	{"go1.22.1-bigcorp", Version{Major: "1", Minor: "22", Patch: "1", Suffix: "bigcorp"}, false},
+	{"devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000", Version{Major: "1", Minor: "23", Devel: true, Commit: "2b1f1e8"}, false},
+	{"devel go1.23", Version{Major: "1", Minor: "23", Devel: true}, false},
+	{"devel +2b1f1e8 Thu Jan 4 20:00:00 2024 +0000", Version{}, true},
+	{"devel ", Version{}, true},
+	{"devel go1.x-2b1f1e8", Version{}, true},
+	{"1.22.1", Version{}, true},
+	{"go1.21.0 X:loopvar,rangefunc", Version{Major: "1", Minor: "21", Patch: "0", Experiment: "loopvar,rangefunc"}, false},
+	{"go1.22.1-bigcorp X:boringcrypto", Version{Major: "1", Minor: "22", Patch: "1", Suffix: "bigcorp", Experiment: "boringcrypto"}, false},
+	{"devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000 X:rangefunc", Version{Major: "1", Minor: "23", Devel: true, Commit: "2b1f1e8", Experiment: "rangefunc"}, false},
+	{"go1.21.x X:loopvar", Version{}, true},
+}
+
+func TestParseRuntime(t *testing.T) {
+	for _, tt := range parseRuntimeTests {
+		v, err := ParseRuntime(tt.in)
+		if v != tt.out || (err != nil) != tt.err {
+			t.Errorf("ParseRuntime(%q) = %+v, %v, want %+v, err=%v", tt.in, v, err, tt.out, tt.err)
+		}
+	}
+
+	v, err := ParseRuntime("devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := v.String(), "devel go1.23-2b1f1e8"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if w, err := ParseRuntime(v.String()); err != nil || w != v {
+		t.Errorf("ParseRuntime(%q) = %+v, %v, want %+v", v.String(), w, err, v)
+	}
+	if v.Compare(MustParse("go1.23")) < 0 || v.Compare(MustParse("go1.22.5")) <= 0 {
+		t.Errorf("%v compares less than go1.23 or go1.22.5", v)
+	}
+
+	_, err = ParseRuntime("devel go1.021-2b1f1e8")
+	if perr, ok := err.(*ParseError); !ok || perr.Offset != 10 || perr.Reason != ReasonLeadingZero {
+		t.Errorf("ParseRuntime(%q) error = %#v, want leading zero at offset 10", "devel go1.021-2b1f1e8", err)
+	}
+}
+
+func TestExperiments(t *testing.T) {
+	v, err := ParseRuntime("go1.21.0 X:loopvar,rangefunc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := v.Experiments(), []string{"loopvar", "rangefunc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Experiments() = %q, want %q", got, want)
+	}
+	if got, want := v.String(), "go1.21.0 X:loopvar,rangefunc"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if v.Compare(MustParse("go1.21.0")) != 0 {
+		t.Errorf("experiment changes comparison")
+	}
+	if got := MustParse("go1.21.0").Experiments(); got != nil {
+		t.Errorf("Experiments() = %q, want nil", got)
+	}
+}
@@ -0,0 +1,115 @@
+package gover
+
+import (
+	"cmp"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// A DirectiveFile is a go.mod or go.work file found by [ScanTree],
+// with its go and toolchain directives in the form returned by
+// [ModuleRequires].
+type DirectiveFile struct {
+	Path      string // file name, relative to the scanned root, with slashes
+	Module    string // module path, or "" for a go.work file
+	Go        string // go directive, such as "go1.21", or "" if none
+	Toolchain string // toolchain directive, such as "go1.22.3", or "" if none
+	Err       error  // error reading or parsing the file, if any
+}
+
+// A TreeReport summarizes the go and toolchain directives of
+// the go.mod and go.work files in a directory tree.
+type TreeReport struct {
+	Files    []DirectiveFile // all files found, in lexical order
+	Min, Max string          // minimum and maximum go directive, or "" if none
+	Common   string          // most common language version of the go directives
+	Outliers []DirectiveFile // files whose go directive's language version is not Common
+}
+
+// ScanTree walks the directory tree rooted at root and reports the go and
+// toolchain directives of every go.mod and go.work file in it, along with
+// the range of go directives, the most common language version among them,
+// and the files that are outliers from it. Files without a go directive or
+// that cannot be parsed are listed in Files, with any error in Err, but are
+// otherwise not counted. Like the go command, ScanTree skips directories
+// named testdata or vendor and those whose names begin with "." or "_".
+// ScanTree returns an error only if the tree cannot be walked.
+func ScanTree(root string) (*TreeReport, error) {
+	r := new(TreeReport)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if name != "go.mod" && name != "go.work" || !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		r.Files = append(r.Files, scanFile(path, filepath.ToSlash(rel)))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, f := range r.Files {
+		if f.Err != nil || f.Go == "" {
+			continue
+		}
+		if r.Min == "" || Compare(f.Go, r.Min) < 0 {
+			r.Min = f.Go
+		}
+		if r.Max == "" || Compare(f.Go, r.Max) > 0 {
+			r.Max = f.Go
+		}
+		counts[Lang(f.Go)]++
+	}
+	for lang, n := range counts {
+		// Break ties in favor of the newer language version.
+		if c := cmp.Compare(n, counts[r.Common]); r.Common == "" || c > 0 || c == 0 && Compare(lang, r.Common) > 0 {
+			r.Common = lang
+		}
+	}
+	for _, f := range r.Files {
+		if f.Err == nil && f.Go != "" && Lang(f.Go) != r.Common {
+			r.Outliers = append(r.Outliers, f)
+		}
+	}
+	return r, nil
+}
+
+// scanFile reads the directives of the go.mod or go.work file path,
+// whose name relative to the scanned root is rel.
+func scanFile(path, rel string) DirectiveFile {
+	f := DirectiveFile{Path: rel}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		f.Err = err
+		return f
+	}
+	if filepath.Base(path) == "go.work" {
+		wf, err := modfile.ParseWork(path, data, keepVersion)
+		if err == nil {
+			f.Go, f.Toolchain, err = directives(wf.Go, wf.Toolchain)
+		}
+		f.Err = err
+		return f
+	}
+	f.Module = modfile.ModulePath(data)
+	f.Go, f.Toolchain, f.Err = ModuleRequires(data)
+	return f
+}
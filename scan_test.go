@@ -0,0 +1,61 @@
+package gover
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestScanTree(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.work":                 "go 1.22.3\n\nuse ./svc/a\n",
+		"go.mod":                  "module example.com/root\n\ngo 1.22.1\n",
+		"svc/a/go.mod":            "module example.com/a\n\ngo 1.22\n\ntoolchain go1.22.5\n",
+		"svc/b/go.mod":            "module example.com/b\n\ngo 1.20\n",
+		"svc/c/go.mod":            "module example.com/c\n\ngo 1.x\n",
+		"svc/d/go.mod":            "module example.com/d\n",
+		"tools/go.mod":            "module example.com/tools\n\ngo 1.23.0\n",
+		"vendor/x/go.mod":         "module example.com/x\n\ngo 1.10\n",
+		"svc/a/testdata/go.mod":   "module example.com/t\n\ngo 1.11\n",
+		".git/go.mod":             "module example.com/g\n\ngo 1.12\n",
+		"_old/go.mod":             "module example.com/o\n\ngo 1.13\n",
+		"svc/b/internal/go.mod.x": "module example.com/bx\n\ngo 1.14\n",
+	})
+	r, err := ScanTree(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, f := range r.Files {
+		paths = append(paths, f.Path)
+	}
+	want := []string{"go.mod", "go.work", "svc/a/go.mod", "svc/b/go.mod", "svc/c/go.mod", "svc/d/go.mod", "tools/go.mod"}
+	if len(paths) != len(want) {
+		t.Fatalf("ScanTree files = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("ScanTree files = %v, want %v", paths, want)
+			break
+		}
+	}
+	if a := r.Files[2]; a.Module != "example.com/a" || a.Go != "go1.22" || a.Toolchain != "go1.22.5" || a.Err != nil {
+		t.Errorf("svc/a/go.mod = %+v", a)
+	}
+	if r.Files[1].Module != "" || r.Files[1].Go != "go1.22.3" {
+		t.Errorf("go.work = %+v", r.Files[1])
+	}
+	if r.Files[4].Err == nil {
+		t.Errorf("svc/c/go.mod: no error for invalid go directive")
+	}
+	if r.Min != "go1.20" || r.Max != "go1.23.0" || r.Common != "go1.22" {
+		t.Errorf("ScanTree: Min=%q Max=%q Common=%q, want go1.20, go1.23.0, go1.22", r.Min, r.Max, r.Common)
+	}
+	if len(r.Outliers) != 2 || r.Outliers[0].Path != "svc/b/go.mod" || r.Outliers[1].Path != "tools/go.mod" {
+		t.Errorf("ScanTree: Outliers = %v, want svc/b and tools", r.Outliers)
+	}
+
+	if _, err := ScanTree(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("ScanTree of missing directory succeeded")
+	}
+}
@@ -0,0 +1,63 @@
+package gover
+
+import (
+	"strconv"
+	"time"
+)
+
+// The Go release schedule, as described at https://go.dev/s/release:
+// a major release every six months, in February and August,
+// with the go1.22 release in February 2024 fixing the phase.
+// The releases since then have been published in the first or second
+// week of the month; estimates use the second Tuesday.
+const (
+	scheduleAnchorMinor = 22
+	scheduleAnchorYear  = 2024
+	scheduleAnchorMonth = time.February
+
+	releaseInterval = 6 // months between major releases
+)
+
+// ExpectedRelease returns the date on which the first release of the
+// language version lang, such as "go1.26", was or is expected to be published.
+// For versions in the release table embedded in the package, ExpectedRelease
+// returns the actual release date. For later versions, it estimates the date
+// from the release schedule, which calls for a major release every
+// February and August.
+// ExpectedRelease returns false if lang is invalid or is not a Go 1 version
+// released on that schedule.
+func ExpectedRelease(lang string) (time.Time, bool) {
+	if d, ok := ReleaseDate(Lang(lang)); ok {
+		return d, true
+	}
+	v := parse(stripGo(lang))
+	if v.Major != "1" {
+		return time.Time{}, false
+	}
+	minor, err := strconv.Atoi(v.Minor)
+	if err != nil || minor < scheduleAnchorMinor {
+		return time.Time{}, false
+	}
+	return secondTuesday(time.Date(scheduleAnchorYear, scheduleAnchorMonth+time.Month(releaseInterval*(minor-scheduleAnchorMinor)), 1, 0, 0, 0, 0, time.UTC)), true
+}
+
+// secondTuesday returns the second Tuesday of the month containing t,
+// which must be midnight UTC on the first of the month.
+func secondTuesday(t time.Time) time.Time {
+	return t.AddDate(0, 0, (int(time.Tuesday)-int(t.Weekday())+7)%7+7)
+}
+
+// NextScheduledMinor returns the next language version expected to be
+// released after the current time, along with its expected release date,
+// as computed by ExpectedRelease.
+func NextScheduledMinor() (lang string, date time.Time) {
+	return nextScheduledMinor(time.Now())
+}
+
+func nextScheduledMinor(now time.Time) (lang string, date time.Time) {
+	for lang = NextLang(latestReleasedLang()); ; lang = NextLang(lang) {
+		if date, ok := ExpectedRelease(lang); !ok || date.After(now) {
+			return lang, date
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package gover
+
+import (
+	"testing"
+	"time"
+)
+
+var expectedReleaseTests = []struct {
+	lang string
+	date string // "" for false
+}{
+	{"go1.21", "2023-08-08"},
+	{"go1.22", "2024-02-06"},
+	{"go1.25rc1", "2025-08-12"},
+	{"go1.30", "2028-02-08"},
+	{"go1.31", "2028-08-08"},
+	{"go1.31.2", "2028-08-08"},
+	{"go2", ""},
+	{"bad", ""},
+}
+
+func TestExpectedRelease(t *testing.T) {
+	for _, tt := range expectedReleaseTests {
+		date, ok := ExpectedRelease(tt.lang)
+		if got := date.Format(time.DateOnly); ok != (tt.date != "") || ok && got != tt.date {
+			t.Errorf("ExpectedRelease(%q) = %s, %v, want %q", tt.lang, got, ok, tt.date)
+		}
+	}
+
+	// Estimates fall on the second Tuesday of February or August.
+	for lang := range Langs(NextLang(latestReleasedLang()), "go1.40") {
+		date, ok := ExpectedRelease(lang)
+		if !ok || date.Weekday() != time.Tuesday || date.Day() < 8 || date.Day() > 14 ||
+			date.Month() != time.February && date.Month() != time.August {
+			t.Errorf("ExpectedRelease(%q) = %v, %v, want second Tuesday of February or August", lang, date, ok)
+		}
+	}
+}
+
+func TestNextScheduledMinor(t *testing.T) {
+	latest := latestReleasedLang()
+	released, _ := ReleaseDate(latest)
+	lang, date := nextScheduledMinor(released)
+	if want, _ := ExpectedRelease(NextLang(latest)); lang != NextLang(latest) || !date.Equal(want) {
+		t.Errorf("nextScheduledMinor(%v) = %q, %v, want %q, %v", released, lang, date, NextLang(latest), want)
+	}
+
+	now := time.Date(2028, time.March, 1, 0, 0, 0, 0, time.UTC)
+	if lang, date := nextScheduledMinor(now); lang != "go1.31" || date.Format(time.DateOnly) != "2028-08-08" {
+		t.Errorf("nextScheduledMinor(%v) = %q, %v, want go1.31, 2028-08-08", now, lang, date)
+	}
+	if lang, date := NextScheduledMinor(); !date.After(time.Now()) || !IsLang(lang) {
+		t.Errorf("NextScheduledMinor() = %q, %v, want future language version", lang, date)
+	}
+}
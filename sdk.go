@@ -0,0 +1,160 @@
+package gover
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// An SDK is a Go toolchain installed on the local system.
+type SDK struct {
+	Version Version // version recorded in the toolchain's VERSION file
+	Root    string  // root directory of the toolchain, suitable for GOROOT
+}
+
+// InstalledSDKs returns the Go toolchains installed in the "sdk"
+// subdirectory of the user's home directory, where [Install] and the
+// golang.org/dl wrappers install them, and in the additional directories
+// roots, sorted by increasing version. Toolchains of the same
+// version are listed in the order of their root directories.
+//
+// Each toolchain is a subdirectory named by its version, such as
+// ~/sdk/go1.22.1, whose VERSION file records the same version;
+// other subdirectories are ignored. Root directories that do not exist
+// are ignored as well.
+func InstalledSDKs(roots ...string) ([]SDK, error) {
+	if home, err := os.UserHomeDir(); err == nil {
+		roots = append([]string{filepath.Join(home, "sdk")}, roots...)
+	}
+	var sdks []SDK
+	seen := make(map[string]bool)
+	for _, dir := range roots {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range entries {
+			root := filepath.Join(dir, e.Name())
+			if !e.IsDir() || !IsValid(e.Name()) || seen[root] {
+				continue
+			}
+			v, _, err := GorootVersion(root)
+			if err != nil || !v.Equal(MustParse(e.Name())) {
+				continue
+			}
+			seen[root] = true
+			sdks = append(sdks, SDK{Version: v, Root: root})
+		}
+	}
+	slices.SortStableFunc(sdks, func(x, y SDK) int {
+		return x.Version.Compare(y.Version)
+	})
+	return sdks, nil
+}
+
+// GorootVersion returns the version of the Go toolchain whose root
+// directory is dir, as recorded in its VERSION file, along with the time
+// the toolchain was built, if recorded.
+// Since Go 1.21, the file holds the version on its first line and the time
+// on a second line, as in
+//
+//	go1.22.1
+//	time 2024-03-05T22:33:05Z
+//
+// while earlier releases record only the version, without a newline.
+// GorootVersion returns the zero time if the file does not record one.
+// The version is parsed with [ParseRuntime], so development versions
+// and GOEXPERIMENT settings are accepted.
+func GorootVersion(dir string) (Version, time.Time, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "VERSION"))
+	if err != nil {
+		return Version{}, time.Time{}, err
+	}
+	first, rest, _ := strings.Cut(string(data), "\n")
+	first = strings.TrimSpace(first)
+	if first == "" {
+		return Version{}, time.Time{}, fmt.Errorf("%s: empty VERSION file", dir)
+	}
+	v, err := ParseRuntime(first)
+	if err != nil {
+		return Version{}, time.Time{}, fmt.Errorf("%s: VERSION file: %v", dir, err)
+	}
+	var t time.Time
+	for _, line := range strings.Split(rest, "\n") {
+		if s, ok := strings.CutPrefix(strings.TrimSpace(line), "time "); ok {
+			t, err = time.Parse(time.RFC3339, s)
+			if err != nil {
+				return Version{}, time.Time{}, fmt.Errorf("%s: VERSION file: invalid time %q", dir, s)
+			}
+		}
+	}
+	return v, t, nil
+}
+
+// A PathToolchain is a versioned go command found on the PATH,
+// such as the go1.21.6 command installed by golang.org/dl/go1.21.6.
+type PathToolchain struct {
+	Version Version // version named by the command
+	Path    string  // file name of the command
+}
+
+// PathToolchains returns the versioned go commands, such as go1.21.6,
+// found in the directories listed in the PATH environment variable,
+// sorted by increasing version. These are the commands that the go
+// command considers when GOTOOLCHAIN=path.
+// As when searching the PATH for a command, only the first command
+// of each name is returned, and files that are not executable are ignored.
+func PathToolchains() []PathToolchain {
+	var tcs []PathToolchain
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue // as for exec.LookPath, "" is not the current directory
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if runtime.GOOS == "windows" {
+				var ok bool
+				if name, ok = strings.CutSuffix(strings.ToLower(name), ".exe"); !ok {
+					continue
+				}
+			}
+			if !strings.HasPrefix(name, "go1") || !IsValid(name) || seen[name] {
+				continue
+			}
+			file := filepath.Join(dir, e.Name())
+			if !isExecutable(file) {
+				continue
+			}
+			seen[name] = true
+			tcs = append(tcs, PathToolchain{Version: MustParse(name), Path: file})
+		}
+	}
+	slices.SortStableFunc(tcs, func(x, y PathToolchain) int {
+		return x.Version.Compare(y.Version)
+	})
+	return tcs
+}
+
+// isExecutable reports whether file is an executable regular file,
+// following symbolic links.
+func isExecutable(file string) bool {
+	info, err := os.Stat(file)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode()&0o111 != 0
+}
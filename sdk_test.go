@@ -0,0 +1,136 @@
+package gover
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// writeSDK creates a fake toolchain in dir/name whose VERSION file holds version.
+func writeSDK(t *testing.T, dir, name, version string) string {
+	t.Helper()
+	root := filepath.Join(dir, name)
+	if err := os.MkdirAll(root, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if version != "" {
+		if err := os.WriteFile(filepath.Join(root, "VERSION"), []byte(version), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestInstalledSDKs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	sdk := filepath.Join(home, "sdk")
+	other := t.TempDir()
+
+	want := []SDK{
+		{MustParse("go1.20"), writeSDK(t, sdk, "go1.20", "go1.20\n")},
+		{MustParse("go1.21rc2"), writeSDK(t, other, "go1.21rc2", "go1.21rc2")},
+		{MustParse("go1.22.1"), writeSDK(t, sdk, "go1.22.1", "go1.22.1\ntime 2024-03-05T22:33:05Z\n")},
+		{MustParse("go1.22.1"), writeSDK(t, other, "go1.22.1", "go1.22.1\ntime 2024-03-05T22:33:05Z\n")},
+	}
+	writeSDK(t, sdk, "go1.22.0", "go1.22.1\n") // wrong version
+	writeSDK(t, sdk, "go1.23.0", "")           // no VERSION file
+	writeSDK(t, sdk, "go1.24.0", "garbage\n")  // invalid VERSION file
+	writeSDK(t, sdk, "gotip", "devel go1.25-abcdef Mon Jan 1 00:00:00 2025 +0000\n")
+	os.WriteFile(filepath.Join(sdk, "go1.22.1.linux-amd64.tar.gz"), nil, 0o666)
+
+	sdks, err := InstalledSDKs(other, filepath.Join(home, "missing"), sdk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sdks) != len(want) {
+		t.Fatalf("InstalledSDKs() = %v, want %v", sdks, want)
+	}
+	for i := range sdks {
+		if !sdks[i].Version.Equal(want[i].Version) || sdks[i].Root != want[i].Root {
+			t.Errorf("InstalledSDKs()[%d] = %v, want %v", i, sdks[i], want[i])
+		}
+	}
+}
+
+func TestPathToolchains(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses Unix file permissions")
+	}
+	bin1, bin2 := t.TempDir(), t.TempDir()
+	write := func(dir, name string, perm os.FileMode) string {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, []byte("#!/bin/sh\n"), perm); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	want := []PathToolchain{
+		{MustParse("go1.21rc2"), write(bin2, "go1.21rc2", 0o755)},
+		{MustParse("go1.21.6"), write(bin1, "go1.21.6", 0o755)},
+		{MustParse("go1.22.1"), write(bin2, "go1.22.1", 0o755)},
+	}
+	write(bin2, "go1.21.6", 0o755) // shadowed by bin1
+	write(bin1, "go1.23.0", 0o644) // not executable
+	write(bin1, "go", 0o755)
+	write(bin1, "gofmt", 0o755)
+	write(bin1, "go1.x", 0o755)
+	os.Mkdir(filepath.Join(bin1, "go1.24.0"), 0o755)
+	t.Setenv("PATH", bin1+string(filepath.ListSeparator)+filepath.Join(bin1, "missing")+string(filepath.ListSeparator)+bin2)
+
+	tcs := PathToolchains()
+	if len(tcs) != len(want) {
+		t.Fatalf("PathToolchains() = %v, want %v", tcs, want)
+	}
+	for i := range tcs {
+		if !tcs[i].Version.Equal(want[i].Version) || tcs[i].Path != want[i].Path {
+			t.Errorf("PathToolchains()[%d] = %v, want %v", i, tcs[i], want[i])
+		}
+	}
+}
+
+var gorootVersionTests = []struct {
+	file    string
+	version string
+	time    string
+	ok      bool
+}{
+	{"go1.22.1\ntime 2024-03-05T22:33:05Z\n", "go1.22.1", "2024-03-05T22:33:05Z", true},
+	{"go1.21.0\ntime 2023-08-04T20:14:06Z", "go1.21.0", "2023-08-04T20:14:06Z", true},
+	{"go1.20.5", "go1.20.5", "", true},
+	{"go1.20.5\n", "go1.20.5", "", true},
+	{"devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000\n", "go1.23", "", true},
+	{"go1.21.0 X:loopvar\n", "go1.21.0", "", true},
+	{"go1.22.1\ntime yesterday\n", "", "", false},
+	{"1.22.1\n", "", "", false},
+	{"\n", "", "", false},
+	{"", "", "", false},
+}
+
+func TestGorootVersion(t *testing.T) {
+	for _, tt := range gorootVersionTests {
+		dir := t.TempDir()
+		os.WriteFile(filepath.Join(dir, "VERSION"), []byte(tt.file), 0o666)
+		v, tm, err := GorootVersion(dir)
+		if (err == nil) != tt.ok {
+			t.Errorf("GorootVersion(%q) error = %v, want ok=%v", tt.file, err, tt.ok)
+			continue
+		}
+		if !tt.ok {
+			continue
+		}
+		var want time.Time
+		if tt.time != "" {
+			want, _ = time.Parse(time.RFC3339, tt.time)
+		}
+		if !v.Equal(MustParse(tt.version)) || !tm.Equal(want) {
+			t.Errorf("GorootVersion(%q) = %v, %v, want %s, %v", tt.file, v, tm, tt.version, want)
+		}
+	}
+	if _, _, err := GorootVersion(t.TempDir()); err == nil {
+		t.Errorf("GorootVersion of directory without VERSION succeeded")
+	}
+}
@@ -0,0 +1,389 @@
+package gover
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// ToSemver returns the semantic version, in the form used by
+// golang.org/x/mod/semver, that corresponds to the Go version x,
+// or the empty string if x is invalid or has no semantic version.
+// For example:
+//
+//	ToSemver("go1.21.3") = "v1.21.3"
+//	ToSemver("go1.20") = "v1.20.0"
+//	ToSemver("go1.21rc2") = "v1.21.0-rc.2"
+//	ToSemver("go1.9.2rc2") = "v1.9.2-rc.2"
+//	ToSemver("go1.21") = "v1.21.0-0"
+//	ToSemver("go1.21.3-bigcorp") = "v1.21.3+bigcorp"
+//
+// The results order the same as the Go versions, as compared by [Compare],
+// with one caveat: since Go 1.21, a language version such as go1.21
+// precedes the series' prereleases, and so it maps to the lowest
+// prerelease, "v1.21.0-0", with which systems that hide prereleases
+// treat it as one. A custom suffix becomes build metadata, which semantic
+// versioning ignores in comparisons, as Compare ignores the suffix;
+// a suffix that is not valid build metadata has no semantic version.
+// This is the mapping the Go vulnerability database uses for the
+// standard library, except that it omits the "v" prefix.
+func ToSemver(x string) string {
+	v, err := Parse(x)
+	if err != nil {
+		return ""
+	}
+	s := "v" + v.Major + "." + v.Minor + "." + cmp.Or(v.Patch, "0")
+	switch {
+	case v.Kind != "" && v.Pre != "":
+		s += "-" + v.Kind + "." + v.Pre
+	case v.Kind != "":
+		s += "-" + v.Kind
+	case v.Patch == "":
+		s += "-0"
+	}
+	if v.Suffix != "" {
+		s += "+" + v.Suffix
+	}
+	if !semver.IsValid(s) {
+		return ""
+	}
+	return s
+}
+
+// SemverKey returns a key for the Go version v under which versions sort
+// as with [Compare] when the keys are ordered by golang.org/x/mod/semver,
+// as in stores that already order module versions that way.
+// The key is the semantic version given by [ToSemver], in which a
+// language version such as go1.21 becomes "v1.21.0-0", preceding the
+// key "v1.21.0-rc.1" of go1.21rc1, which in turn precedes "v1.21.0" for
+// go1.21.0. Unlike ToSemver, SemverKey drops a custom suffix that is not
+// valid build metadata rather than failing, since semantic versioning
+// ignores build metadata in comparisons anyway.
+// If v is invalid, SemverKey returns the empty string.
+func SemverKey(v string) string {
+	x, err := Parse(v)
+	if err != nil {
+		return ""
+	}
+	if x.Suffix != "" && !semver.IsValid("v0.0.0+"+x.Suffix) {
+		x.Suffix = ""
+	}
+	return ToSemver(x.String())
+}
+
+// FromSemver returns the Go version that corresponds to the semantic
+// version s, reversing [ToSemver], or the empty string if s is invalid or
+// names no Go version. The "v" prefix of s may be omitted, as it is in
+// the Go vulnerability database.
+// For example:
+//
+//	FromSemver("v1.21.3") = "go1.21.3"
+//	FromSemver("v1.20.0") = "go1.20"
+//	FromSemver("v1.21.0-rc.2") = "go1.21rc2"
+//	FromSemver("v1.21.0-0") = "go1.21"
+//	FromSemver("1.21.3+bigcorp") = "go1.21.3-bigcorp"
+//
+// Prereleases other than "0" must have the form kind.N or kind,
+// where kind is a lower-case word such as "rc", and those of a series'
+// first release must have patch number 0.
+func FromSemver(s string) string {
+	if !strings.HasPrefix(s, "v") {
+		s = "v" + s
+	}
+	if !semver.IsValid(s) {
+		return ""
+	}
+	pre := strings.TrimPrefix(semver.Prerelease(s), "-")
+	build := strings.TrimPrefix(semver.Build(s), "+")
+	nums := strings.Split(strings.TrimPrefix(semver.Canonical(s), "v"), ".")
+	nums[2], _, _ = strings.Cut(nums[2], "-")
+	x := "go" + nums[0] + "." + nums[1]
+	switch {
+	case pre == "0":
+		if nums[2] != "0" {
+			return ""
+		}
+	case pre != "":
+		// The prereleases of a series' first release omit the patch number.
+		if nums[2] != "0" {
+			x += "." + nums[2]
+		}
+		kind, n, _ := strings.Cut(pre, ".")
+		if strings.Trim(kind, "abcdefghijklmnopqrstuvwxyz") != "" || n != "" && !isDecimal(n) {
+			return ""
+		}
+		x += kind + n
+	default:
+		x += "." + nums[2]
+	}
+	if build != "" {
+		x += "-" + build
+	}
+	v, err := Parse(x)
+	if err != nil {
+		return ""
+	}
+	return v.Canonical()
+}
+
+// ErrUntranslatable reports that a version constraint has no equivalent
+// in the syntax it is being translated into; see [ParseSemverConstraint]
+// and [Constraint.SemverConstraint]. Errors wrapping it are returned for
+// such constraints, as opposed to malformed ones.
+var ErrUntranslatable = errors.New("constraint cannot be translated")
+
+// ParseSemverConstraint translates a constraint on semantic versions
+// written in the syntax of github.com/Masterminds/semver, such as
+// ">= 1.21.0, < 1.24" or "~1.22 || ^1.23.2", into a Constraint on the
+// corresponding Go versions, as mapped by [FromSemver].
+// The result's String method returns the constraint in ParseConstraint syntax.
+//
+// The syntax includes the comparisons =, !=, >, >=, <, and <=, optionally
+// with a space before the version; the tilde (~ or ~>) and caret (^) ranges;
+// hyphen ranges such as "1.20 - 1.22"; and the wildcards x, X, and *.
+// Terms are separated by commas or spaces, meaning and, and by ||, meaning or.
+// A partial version such as "1.21" is treated like the wildcard "1.21.x",
+// and so "<= 1.21" means "< 1.22.0".
+//
+// Unlike Masterminds/semver, which matches prereleases only against
+// comparisons that name a prerelease, the result compares Go prereleases
+// like any other version: "< 1.22.0" matches go1.22rc1, for example, since
+// it precedes go1.22.0. To match only releases, also check [IsRelease].
+// Versions that name no Go version, such as "1.21.0-rc.1.2", are
+// untranslatable, and ParseSemverConstraint returns an error wrapping
+// [ErrUntranslatable].
+func ParseSemverConstraint(s string) (Constraint, error) {
+	var alts []string
+	for _, alt := range strings.Split(s, "||") {
+		fields := strings.Fields(strings.ReplaceAll(alt, ",", " "))
+		var terms []string
+		for i := 0; i < len(fields); i++ {
+			f := fields[i]
+			op := f[:len(f)-len(strings.TrimLeft(f, "<>=!~^"))]
+			x := f[len(op):]
+			if x == "" && op != "" && i+1 < len(fields) {
+				// Operator separated from its version, as in ">= 1.20".
+				i++
+				x = fields[i]
+			}
+			var term string
+			var err error
+			if op == "" && i+2 < len(fields) && fields[i+1] == "-" {
+				term, err = semverHyphen(x, fields[i+2])
+				i += 2
+			} else {
+				term, err = semverTerm(op, x)
+			}
+			if err != nil {
+				return Constraint{}, fmt.Errorf("semver constraint %q: %w", s, err)
+			}
+			terms = append(terms, term)
+		}
+		if len(terms) == 0 {
+			return Constraint{}, fmt.Errorf("invalid semver constraint %q", s)
+		}
+		alts = append(alts, strings.Join(terms, " && "))
+	}
+	text := alts[0]
+	if len(alts) > 1 {
+		text = "(" + strings.Join(alts, ") || (") + ")"
+	}
+	return ParseConstraint(text)
+}
+
+// semverTerm translates the Masterminds/semver comparison op x
+// into a constraint term.
+func semverTerm(op, x string) (string, error) {
+	nums, pre, err := parseSemverPartial(x)
+	if err != nil {
+		return "", err
+	}
+	// The wildcard matching every version.
+	if len(nums) == 0 {
+		switch op {
+		case "", "=", ">=", "=>", "<=", "=<", "~", "~>", "^":
+			return "*", nil
+		}
+		return "", fmt.Errorf("%s%s: %w: matches no versions", op, x, ErrUntranslatable)
+	}
+
+	// A partial version denotes the range [lo, hi).
+	lo, err := semverGo(semverFill(nums), pre)
+	if err != nil {
+		return "", err
+	}
+	hi := ""
+	if len(nums) < 3 || op == "~" || op == "~>" || op == "^" {
+		i := len(nums) - 1
+		switch op {
+		case "~", "~>":
+			i = min(i, 1)
+		case "^":
+			switch {
+			case nums[0] != "0" || len(nums) == 1:
+				i = 0
+			case nums[1] != "0" || len(nums) == 2:
+				i = 1
+			}
+		}
+		bumped := slices.Clone(semverFill(nums))
+		bumped[i] = IncInt(bumped[i])
+		for j := i + 1; j < 3; j++ {
+			bumped[j] = "0"
+		}
+		if hi, err = semverGo(bumped, ""); err != nil {
+			return "", err
+		}
+	}
+
+	switch op {
+	case "", "=", "~", "~>", "^":
+		if hi == "" {
+			return lo, nil
+		}
+		return ">=" + lo + " && <" + hi, nil
+	case "!=":
+		if hi == "" {
+			return "(<" + lo + " || >" + lo + ")", nil
+		}
+		return "(<" + lo + " || >=" + hi + ")", nil
+	case ">":
+		if hi == "" {
+			return ">" + lo, nil
+		}
+		return ">=" + hi, nil
+	case ">=", "=>":
+		return ">=" + lo, nil
+	case "<":
+		return "<" + lo, nil
+	case "<=", "=<":
+		if hi == "" {
+			return "<=" + lo, nil
+		}
+		return "<" + hi, nil
+	}
+	return "", fmt.Errorf("unknown operator %q", op)
+}
+
+// semverHyphen translates the Masterminds/semver hyphen range "x - y"
+// into a constraint term.
+func semverHyphen(x, y string) (string, error) {
+	lo, err := semverTerm(">=", x)
+	if err != nil {
+		return "", err
+	}
+	hi, err := semverTerm("<=", y)
+	if err != nil {
+		return "", err
+	}
+	return lo + " && " + hi, nil
+}
+
+// parseSemverPartial parses a possibly partial semantic version,
+// such as "1.21", "v1.21.x", or "1.21.0-rc.1", returning its numbers up to
+// the first missing or wildcard one, and its prerelease, if any.
+func parseSemverPartial(x string) (nums []string, pre string, err error) {
+	x = strings.TrimPrefix(x, "v")
+	if strings.Contains(x, "+") {
+		return nil, "", fmt.Errorf("%s: %w: build metadata", x, ErrUntranslatable)
+	}
+	x, pre, _ = strings.Cut(x, "-")
+	parts := strings.Split(x, ".")
+	if len(parts) > 3 {
+		return nil, "", fmt.Errorf("invalid semantic version %q", x)
+	}
+	for _, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			break
+		}
+		if !isDecimal(p) {
+			return nil, "", fmt.Errorf("invalid semantic version %q", x)
+		}
+		nums = append(nums, p)
+	}
+	if pre != "" && len(nums) < 3 {
+		return nil, "", fmt.Errorf("invalid semantic version %q: prerelease of partial version", x)
+	}
+	return nums, pre, nil
+}
+
+// semverFill returns nums extended with zeros to three numbers.
+func semverFill(nums []string) []string {
+	for len(nums) < 3 {
+		nums = append(nums, "0")
+	}
+	return nums
+}
+
+// semverGo returns the Go version corresponding to the semantic version
+// with the given numbers and prerelease.
+func semverGo(nums []string, pre string) (string, error) {
+	s := strings.Join(nums, ".")
+	if pre != "" {
+		s += "-" + pre
+	}
+	x := FromSemver(s)
+	if x == "" {
+		return "", fmt.Errorf("%s: %w: not a Go version", s, ErrUntranslatable)
+	}
+	return x, nil
+}
+
+// SemverConstraint returns a constraint in the syntax of
+// github.com/Masterminds/semver matching the semantic versions that
+// correspond, as mapped by [ToSemver], to the Go versions matching c,
+// such as ">=1.21.0, <1.24.0-0" for ">=go1.21.0, <go1.24".
+// The start of a series, as in the bounds of "~go1.21" and "go1.21.x",
+// becomes its lowest prerelease, as in "<1.22.0-0".
+//
+// Masterminds/semver matches prereleases only against comparisons that
+// name a prerelease, so the result may not match Go prereleases that c
+// matches. The zero Constraint, and any other empty one, has no
+// equivalent, and SemverConstraint returns an error wrapping
+// [ErrUntranslatable].
+func (c Constraint) SemverConstraint() (string, error) {
+	if len(c.ranges) == 0 {
+		return "", fmt.Errorf("constraint %q: %w: matches no versions", c.text, ErrUntranslatable)
+	}
+	var alts []string
+	for _, r := range c.ranges {
+		var terms []string
+		switch {
+		case r.lo.v == (Version{}) && r.hi.v == (Version{}):
+			terms = append(terms, "*")
+		case r.lo == r.hi:
+			terms = append(terms, "="+boundSemver(r.lo.v))
+		default:
+			if r.lo.v != (Version{}) {
+				op := ">"
+				if r.lo.inc {
+					op = ">="
+				}
+				terms = append(terms, op+boundSemver(r.lo.v))
+			}
+			if r.hi.v != (Version{}) {
+				op := "<"
+				if r.hi.inc {
+					op = "<="
+				}
+				terms = append(terms, op+boundSemver(r.hi.v))
+			}
+		}
+		alts = append(alts, strings.Join(terms, ", "))
+	}
+	return strings.Join(alts, " || "), nil
+}
+
+// boundSemver returns the semantic version, without the "v" prefix,
+// corresponding to the bound version v, which may be the start of a series.
+func boundSemver(v Version) string {
+	if v.Patch == "" && v.Kind == "" {
+		// The start of a series precedes all its versions.
+		return v.Major + "." + cmp.Or(v.Minor, "0") + ".0-0"
+	}
+	return strings.TrimPrefix(ToSemver(v.String()), "v")
+}
@@ -0,0 +1,221 @@
+package gover
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"golang.org/x/mod/semver"
+)
+
+var semverTests = []struct {
+	goVersion string
+	semver    string
+}{
+	{"go1", "v1.0.0"},
+	{"go1.9", "v1.9.0"},
+	{"go1.9beta1", "v1.9.0-beta.1"},
+	{"go1.9rc1", "v1.9.0-rc.1"},
+	{"go1.9.2rc2", "v1.9.2-rc.2"},
+	{"go1.20", "v1.20.0"},
+	{"go1.21", "v1.21.0-0"},
+	{"go1.21rc2", "v1.21.0-rc.2"},
+	{"go1.21rc", "v1.21.0-rc"},
+	{"go1.21.0", "v1.21.0"},
+	{"go1.21.3", "v1.21.3"},
+	{"go1.21.3-bigcorp", "v1.21.3+bigcorp"},
+	{"go1.21.3-big.corp-2", "v1.21.3+big.corp-2"},
+}
+
+func TestToSemver(t *testing.T) {
+	for _, tt := range semverTests {
+		if out := ToSemver(tt.goVersion); out != tt.semver {
+			t.Errorf("ToSemver(%q) = %q, want %q", tt.goVersion, out, tt.semver)
+		}
+	}
+	for _, x := range []string{"", "1.21.3", "go1.21.3-big_corp", "bad"} {
+		if out := ToSemver(x); out != "" {
+			t.Errorf("ToSemver(%q) = %q, want \"\"", x, out)
+		}
+	}
+}
+
+func TestFromSemver(t *testing.T) {
+	for _, tt := range semverTests {
+		if out := FromSemver(tt.semver); out != tt.goVersion {
+			t.Errorf("FromSemver(%q) = %q, want %q", tt.semver, out, tt.goVersion)
+		}
+	}
+	for in, want := range map[string]string{
+		"1.21.3":         "go1.21.3",
+		"1.21.0-rc.2":    "go1.21rc2",
+		"v1.21":          "go1.21.0",
+		"v1.20.0-0":      "go1.20",
+		"v1.21.3-0":      "",
+		"v1.21.1-rc.1":   "go1.21.1rc1",
+		"v1.21.0-RC.1":   "",
+		"v1.21.0-rc.1.2": "",
+		"v1.21.0-0.dev":  "",
+		"v01.2.3":        "",
+		"bad":            "",
+		"":               "",
+	} {
+		if out := FromSemver(in); out != want {
+			t.Errorf("FromSemver(%q) = %q, want %q", in, out, want)
+		}
+	}
+}
+
+func TestSemverOrder(t *testing.T) {
+	vs := []string{"go1.9beta1", "go1.9rc1", "go1.9", "go1.9.2rc2", "go1.9.2", "go1.20", "go1.21", "go1.21rc1", "go1.21rc2", "go1.21.0", "go1.21.3", "go1.22"}
+	for i, x := range vs {
+		for _, y := range vs[i:] {
+			if got, want := semver.Compare(ToSemver(x), ToSemver(y)), Compare(x, y); got != want {
+				t.Errorf("semver.Compare(ToSemver(%s), ToSemver(%s)) = %d, want %d", x, y, got, want)
+			}
+		}
+	}
+	if !slices.IsSortedFunc(vs, Compare) {
+		t.Errorf("test versions are not sorted")
+	}
+}
+
+func TestSemverKey(t *testing.T) {
+	vs := []string{"go1.22.0", "go1.21.3-big_corp", "go1", "go1.21", "go1.9.2rc2", "go1.21rc1", "go1.20", "go1.21.0", "go1.9beta1", "go1.21rc2", "go1.9"}
+	keys := make(map[string]string)
+	for _, v := range vs {
+		keys[v] = SemverKey(v)
+		if !semver.IsValid(keys[v]) {
+			t.Errorf("SemverKey(%q) = %q, not a valid semantic version", v, keys[v])
+		}
+	}
+	bySemver := slices.Clone(vs)
+	slices.SortStableFunc(bySemver, func(x, y string) int { return semver.Compare(keys[x], keys[y]) })
+	byGo := slices.Clone(vs)
+	slices.SortStableFunc(byGo, Compare)
+	if !slices.Equal(bySemver, byGo) {
+		t.Errorf("sorted by SemverKey: %v\nsorted by Compare: %v", bySemver, byGo)
+	}
+	for v, want := range map[string]string{
+		"go1.21":            "v1.21.0-0",
+		"go1.21.3-bigcorp":  "v1.21.3+bigcorp",
+		"go1.21.3-big_corp": "v1.21.3",
+		"1.21.3":            "",
+		"bad":               "",
+	} {
+		if key := SemverKey(v); key != want {
+			t.Errorf("SemverKey(%q) = %q, want %q", v, key, want)
+		}
+	}
+}
+
+// semverConstraintVersions are the versions checked against
+// translated constraints.
+var semverConstraintVersions = []string{
+	"go1.19.5", "go1.20rc1", "go1.20", "go1.20.3", "go1.21", "go1.21rc1", "go1.21.0", "go1.21.5",
+	"go1.22rc1", "go1.22.0", "go1.22.4", "go1.23.0", "go1.24.1", "go2",
+}
+
+var parseSemverConstraintTests = []struct {
+	in    string
+	text  string
+	match []string
+}{
+	{">= 1.21.0, < 1.23", ">=go1.21.0 && <go1.23.0", []string{"go1.21.0", "go1.21.5", "go1.22rc1", "go1.22.0", "go1.22.4"}},
+	{">=v1.21.0 <=1.21", ">=go1.21.0 && <go1.22.0", []string{"go1.21.0", "go1.21.5", "go1.22rc1"}},
+	{"1.20.x", ">=go1.20 && <go1.21.0", []string{"go1.20", "go1.20.3", "go1.21", "go1.21rc1"}},
+	{"=1.22.4", "go1.22.4", []string{"go1.22.4"}},
+	{"~1.21.2", ">=go1.21.2 && <go1.22.0", []string{"go1.21.5", "go1.22rc1"}},
+	{"~> 1.21", ">=go1.21.0 && <go1.22.0", []string{"go1.21.0", "go1.21.5", "go1.22rc1"}},
+	{"^1.22.1", ">=go1.22.1 && <go2.0", []string{"go1.22.4", "go1.23.0", "go1.24.1"}},
+	{"> 1.22", ">=go1.23.0", []string{"go1.23.0", "go1.24.1", "go2"}},
+	{"!=1.22.4, 1.22.x", "(<go1.22.4 || >go1.22.4) && >=go1.22.0 && <go1.23.0", []string{"go1.22.0"}},
+	{"1.19 - 1.20", ">=go1.19 && <go1.21.0", []string{"go1.19.5", "go1.20rc1", "go1.20", "go1.20.3", "go1.21", "go1.21rc1"}},
+	{">=1.21.0-rc.1 <1.21.0", ">=go1.21rc1 && <go1.21.0", []string{"go1.21rc1"}},
+	{"<1.20 || >=1.24", "(<go1.20) || (>=go1.24.0)", []string{"go1.19.5", "go1.20rc1", "go1.24.1", "go2"}},
+	{"*", "*", semverConstraintVersions},
+}
+
+func TestParseSemverConstraint(t *testing.T) {
+	for _, tt := range parseSemverConstraintTests {
+		c, err := ParseSemverConstraint(tt.in)
+		if err != nil {
+			t.Errorf("ParseSemverConstraint(%q): %v", tt.in, err)
+			continue
+		}
+		if c.String() != tt.text {
+			t.Errorf("ParseSemverConstraint(%q) = %q, want %q", tt.in, c, tt.text)
+		}
+		var match []string
+		for _, v := range semverConstraintVersions {
+			if c.Check(v) {
+				match = append(match, v)
+			}
+		}
+		if !slices.Equal(match, tt.match) {
+			t.Errorf("ParseSemverConstraint(%q) matches %v, want %v", tt.in, match, tt.match)
+		}
+	}
+}
+
+func TestParseSemverConstraintErrors(t *testing.T) {
+	for in, untranslatable := range map[string]bool{
+		"":                 false,
+		">= 1.21 ||":       false,
+		">=1.2.3.4":        false,
+		">=1.021":          false,
+		"%1.2":             false,
+		"1.2-rc.1":         false,
+		">=1.21.0-rc.1.2":  true,
+		">=1.21.0+bigcorp": true,
+		"<*":               true,
+	} {
+		_, err := ParseSemverConstraint(in)
+		if err == nil || errors.Is(err, ErrUntranslatable) != untranslatable {
+			t.Errorf("ParseSemverConstraint(%q) = %v, want error (untranslatable: %v)", in, err, untranslatable)
+		}
+	}
+}
+
+var semverConstraintTests = []struct {
+	in, out string
+}{
+	{">=go1.21.0, <go1.24", ">=1.21.0, <1.24.0-0"},
+	{"go1.21.x", ">=1.21.0, <1.22.0-0"},
+	{"~go1.20", ">=1.20.0, <1.21.0-0"},
+	{"^go1.21.3", ">=1.21.3, <2.0.0-0"},
+	{"=go1.22.4 || >go1.23rc1", "=1.22.4 || >1.23.0-rc.1"},
+	{"<=go1.21", "<=1.21.0-0"},
+	{"*", "*"},
+}
+
+func TestSemverConstraint(t *testing.T) {
+	for _, tt := range semverConstraintTests {
+		c, err := ParseConstraint(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := c.SemverConstraint()
+		if out != tt.out || err != nil {
+			t.Errorf("SemverConstraint(%q) = %q, %v, want %q", tt.in, out, err, tt.out)
+			continue
+		}
+		// Translating back matches the same versions.
+		back, err := ParseSemverConstraint(out)
+		if err != nil {
+			t.Errorf("ParseSemverConstraint(%q): %v", out, err)
+			continue
+		}
+		for _, v := range semverConstraintVersions {
+			if c.Check(v) != back.Check(v) {
+				t.Errorf("ParseSemverConstraint(%q).Check(%s) = %v, want %v", out, v, back.Check(v), c.Check(v))
+			}
+		}
+	}
+	c, _ := ParseConstraint(">=go1.22 && <go1.21")
+	for _, c := range []Constraint{{}, c} {
+		if out, err := c.SemverConstraint(); !errors.Is(err, ErrUntranslatable) {
+			t.Errorf("SemverConstraint(%q) = %q, %v, want ErrUntranslatable", c, out, err)
+		}
+	}
+}
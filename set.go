@@ -0,0 +1,112 @@
+package gover
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// A VersionSet is a set of Go versions.
+// Versions are stored in the canonical form returned by Normalize,
+// so that spellings of the same version, such as "go1.20", "go1.20.0",
+// and " 1.20", are a single element.
+// The zero VersionSet is an empty set ready to use.
+type VersionSet struct {
+	m map[string]struct{}
+}
+
+// NewVersionSet returns a set holding the given versions.
+// It returns an error if any version is invalid.
+func NewVersionSet(versions ...string) (*VersionSet, error) {
+	s := new(VersionSet)
+	for _, v := range versions {
+		if err := s.Add(v); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Add adds the version v to the set.
+// It returns an error, leaving the set unchanged, if v is invalid.
+func (s *VersionSet) Add(v string) error {
+	key, err := Normalize(v)
+	if err != nil {
+		return err
+	}
+	if s.m == nil {
+		s.m = make(map[string]struct{})
+	}
+	s.m[key] = struct{}{}
+	return nil
+}
+
+// Remove removes the version v from the set, if present.
+func (s *VersionSet) Remove(v string) {
+	if key, err := Normalize(v); err == nil {
+		delete(s.m, key)
+	}
+}
+
+// Contains reports whether the version v is in the set.
+// Invalid versions are never in the set.
+func (s *VersionSet) Contains(v string) bool {
+	key, err := Normalize(v)
+	if err != nil {
+		return false
+	}
+	_, ok := s.m[key]
+	return ok
+}
+
+// Len returns the number of versions in the set.
+func (s *VersionSet) Len() int {
+	return len(s.m)
+}
+
+// Versions returns the versions in the set in canonical form,
+// sorted in increasing order using CompareDetail, so that versions
+// differing only in their suffixes are always listed in the same order.
+func (s *VersionSet) Versions() []string {
+	vs := make([]string, 0, len(s.m))
+	for v := range s.m {
+		vs = append(vs, v)
+	}
+	slices.SortFunc(vs, func(x, y string) int {
+		return cmp.Or(CompareDetail(x, y), strings.Compare(x, y))
+	})
+	return vs
+}
+
+// Union returns a new set holding the versions in either s or t.
+func (s *VersionSet) Union(t *VersionSet) *VersionSet {
+	u := &VersionSet{m: maps.Clone(s.m)}
+	if u.m == nil {
+		u.m = make(map[string]struct{})
+	}
+	maps.Copy(u.m, t.m)
+	return u
+}
+
+// Intersect returns a new set holding the versions in both s and t.
+func (s *VersionSet) Intersect(t *VersionSet) *VersionSet {
+	u := &VersionSet{m: make(map[string]struct{})}
+	for v := range s.m {
+		if _, ok := t.m[v]; ok {
+			u.m[v] = struct{}{}
+		}
+	}
+	return u
+}
+
+// Difference returns a new set holding the versions in s but not in t.
+func (s *VersionSet) Difference(t *VersionSet) *VersionSet {
+	u := &VersionSet{m: make(map[string]struct{})}
+	for v := range s.m {
+		if _, ok := t.m[v]; !ok {
+			u.m[v] = struct{}{}
+		}
+	}
+	return u
+}
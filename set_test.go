@@ -0,0 +1,93 @@
+package gover
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestVersionSet(t *testing.T) {
+	var s VersionSet
+	if s.Len() != 0 || s.Contains("go1.21.0") || len(s.Versions()) != 0 {
+		t.Errorf("zero VersionSet is not empty")
+	}
+	for _, v := range []string{"go1.22.1", "go1.20", " 1.20.0", "v1.21.3", "go1.21rc1", "go1.22.1"} {
+		if err := s.Add(v); err != nil {
+			t.Fatalf("Add(%q): %v", v, err)
+		}
+	}
+	if err := s.Add("go1.021"); err == nil {
+		t.Errorf("Add(%q) succeeded, want error", "go1.021")
+	}
+	want := []string{"go1.20", "go1.21rc1", "go1.21.3", "go1.22.1"}
+	if got := s.Versions(); !slices.Equal(got, want) {
+		t.Errorf("Versions() = %q, want %q", got, want)
+	}
+	if s.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", s.Len(), len(want))
+	}
+	for _, v := range []string{"go1.20.0", "1.21.3", "go1.22.1"} {
+		if !s.Contains(v) {
+			t.Errorf("Contains(%q) = false, want true", v)
+		}
+	}
+	for _, v := range []string{"go1.21", "go1.21.0", "bad"} {
+		if s.Contains(v) {
+			t.Errorf("Contains(%q) = true, want false", v)
+		}
+	}
+	s.Remove("go1.20.0")
+	s.Remove("bad")
+	if s.Contains("go1.20") || s.Len() != 3 {
+		t.Errorf("after Remove(go1.20.0): %q", s.Versions())
+	}
+}
+
+func TestVersionSetSuffixOrder(t *testing.T) {
+	want := []string{"go1.21.3", "go1.21.3-a", "go1.21.3-b", "go1.21.3-c", "go1.21.3-d"}
+	s, err := NewVersionSet("go1.21.3-c", "go1.21.3", "go1.21.3-d", "go1.21.3-a", "go1.21.3-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 100 {
+		if got := s.Versions(); !slices.Equal(got, want) {
+			t.Fatalf("Versions() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestVersionSetAlgebra(t *testing.T) {
+	s, err := NewVersionSet("go1.20", "go1.21.0", "go1.22.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := NewVersionSet("go1.20.0", "go1.22.0", "go1.23.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var empty VersionSet
+	tests := []struct {
+		name string
+		set  *VersionSet
+		want []string
+	}{
+		{"Union", s.Union(u), []string{"go1.20", "go1.21.0", "go1.22.0", "go1.23.0"}},
+		{"Intersect", s.Intersect(u), []string{"go1.20", "go1.22.0"}},
+		{"Difference", s.Difference(u), []string{"go1.21.0"}},
+		{"empty Union", empty.Union(s), []string{"go1.20", "go1.21.0", "go1.22.0"}},
+		{"empty Intersect", s.Intersect(&empty), []string{}},
+		{"empty Difference", s.Difference(&empty), []string{"go1.20", "go1.21.0", "go1.22.0"}},
+	}
+	for _, tt := range tests {
+		if got := tt.set.Versions(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	// The inputs are unchanged.
+	if got := s.Versions(); !slices.Equal(got, []string{"go1.20", "go1.21.0", "go1.22.0"}) {
+		t.Errorf("s modified: %q", got)
+	}
+
+	if _, err := NewVersionSet("go1.21.0", "go1.21.x"); err == nil {
+		t.Errorf("NewVersionSet(go1.21.0, go1.21.x) succeeded, want error")
+	}
+}
@@ -0,0 +1,109 @@
+package gover
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolveSetupGo returns the version from available that the
+// actions/setup-go GitHub Action would install for the go-version spec,
+// or an error if the spec is invalid or no available version matches it.
+//
+// The spec uses setup-go's semver-based dialect, in which versions omit
+// the "go" prefix:
+//
+//   - "1.21" and "1.21.x" mean the newest go1.21 release.
+//   - "1.21.3" means exactly go1.21.3.
+//   - "1.21.0-rc.1" means the prerelease go1.21rc1.
+//   - ">=1.20", "<1.22", "^1.20", and "~1.21" are ranges, which can be combined,
+//     as in ">=1.20 <1.22" and "1.20.x || 1.22.x".
+//   - "stable" and "oldstable" are resolved using ResolveAlias.
+//
+// Prereleases match only when the spec names a prerelease.
+// Invalid entries in available are ignored.
+func ResolveSetupGo(spec string, available []string) (string, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "stable" || spec == "oldstable" {
+		return ResolveAlias(spec, available)
+	}
+	c, pre, err := setupGoConstraint(spec)
+	if err != nil {
+		return "", err
+	}
+	var best string
+	for _, v := range available {
+		if IsValid(v) && (pre || IsRelease(v)) && c.Check(v) && (best == "" || Compare(v, best) > 0) {
+			best = v
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("no available version matches go-version %q", spec)
+	}
+	return best, nil
+}
+
+// setupGoConstraint translates a setup-go version spec into a Constraint,
+// also reporting whether the spec names a prerelease.
+func setupGoConstraint(spec string) (c Constraint, pre bool, err error) {
+	var alts []string
+	for _, alt := range strings.Split(spec, "||") {
+		var terms []string
+		fields := strings.Fields(alt)
+		for i := 0; i < len(fields); i++ {
+			f := fields[i]
+			op := f[:len(f)-len(strings.TrimLeft(f, "<>=^~"))]
+			x := f[len(op):]
+			if x == "" && op != "" && i+1 < len(fields) {
+				// Operator separated from its version, as in ">= 1.20".
+				i++
+				x = fields[i]
+			}
+			v, isPre, ok := setupGoVersion(x, op == "")
+			if !ok {
+				return Constraint{}, false, fmt.Errorf("invalid go-version %q", spec)
+			}
+			pre = pre || isPre
+			terms = append(terms, op+v)
+		}
+		if len(terms) == 0 {
+			return Constraint{}, false, fmt.Errorf("invalid go-version %q", spec)
+		}
+		alts = append(alts, "("+strings.Join(terms, " && ")+")")
+	}
+	c, err = ParseConstraint(strings.Join(alts, " || "))
+	if err != nil {
+		return Constraint{}, false, fmt.Errorf("invalid go-version %q", spec)
+	}
+	return c, pre, nil
+}
+
+// setupGoVersion translates a setup-go version such as "1.21", "1.21.x",
+// or "1.21.0-rc.1" into the Go version or wildcard used in a Constraint.
+// If bare is set, the version appears without an operator,
+// so a partial version such as "1.21" denotes the whole series.
+func setupGoVersion(x string, bare bool) (v string, pre, ok bool) {
+	if x == "x" || x == "*" {
+		return "*", false, true
+	}
+	if base, semverPre, ok := strings.Cut(x, "-"); ok {
+		// Semver prerelease: 1.21.0-rc.1 is go1.21rc1.
+		nums := strings.Split(base, ".")
+		kind, n, ok := strings.Cut(semverPre, ".")
+		if !ok || len(nums) < 2 || len(nums) > 3 || len(nums) == 3 && nums[2] != "0" {
+			return "", false, false
+		}
+		v = "go" + nums[0] + "." + nums[1] + kind + n
+		return v, true, IsPrerelease(v)
+	}
+	v = "go" + x
+	if strings.HasSuffix(x, ".x") || strings.HasSuffix(x, ".*") {
+		return v, false, true
+	}
+	if !IsValid(v) {
+		return "", false, false
+	}
+	if bare && strings.Count(x, ".") < 2 {
+		return v + ".x", false, true
+	}
+	return v, false, true
+}
@@ -0,0 +1,54 @@
+package gover
+
+import "testing"
+
+var setupGoReleases = []string{
+	"go1.20.13", "go1.20.14", "go1.21rc2", "go1.21.0", "go1.21.13",
+	"go1.22.0", "go1.22.12", "go1.23rc1", "go1.19", "go1.19.1",
+}
+
+var resolveSetupGoTests = []struct {
+	spec string
+	out  string
+	err  bool
+}{
+	{"1.21", "go1.21.13", false},
+	{"1.21.x", "go1.21.13", false},
+	{" 1.21.x ", "go1.21.13", false},
+	{"1.21.0", "go1.21.0", false},
+	{"1.19", "go1.19.1", false},
+	{"1.19.0", "go1.19", false},
+	{"1", "go1.22.12", false},
+	{"1.x", "go1.22.12", false},
+	{"x", "go1.22.12", false},
+	{">=1.20", "go1.22.12", false},
+	{">=1.20 <1.22", "go1.21.13", false},
+	{">= 1.20, < 1.22", "", true},
+	{">= 1.20 < 1.22", "go1.21.13", false},
+	{"1.19.x || 1.20.x", "go1.20.14", false},
+	{"^1.20", "go1.22.12", false},
+	{"~1.20.1", "go1.20.14", false},
+	{"1.21.0-rc.2", "go1.21rc2", false},
+	{"1.23.0-rc.1", "go1.23rc1", false},
+	{"1.23-rc.1", "go1.23rc1", false},
+	{">=1.23.0-rc.1", "go1.23rc1", false},
+	{"stable", "go1.22.12", false},
+	{"oldstable", "go1.21.13", false},
+	{"1.24", "", true},
+	{"1.21.3", "", true},
+	{"1.21.1-rc.1", "", true},
+	{"1.021", "", true},
+	{"go1.21", "", true},
+	{"", "", true},
+	{"1.21 ||", "", true},
+	{">>1.21", "", true},
+}
+
+func TestResolveSetupGo(t *testing.T) {
+	for _, tt := range resolveSetupGoTests {
+		out, err := ResolveSetupGo(tt.spec, setupGoReleases)
+		if out != tt.out || (err != nil) != tt.err {
+			t.Errorf("ResolveSetupGo(%q) = %q, %v, want %q, err=%v", tt.spec, out, err, tt.out, tt.err)
+		}
+	}
+}
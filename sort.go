@@ -0,0 +1,19 @@
+package gover
+
+import "slices"
+
+// Sort sorts a slice of versions in increasing order, compared using Compare.
+// The sort is stable: versions that compare equal, such as "go1.20" and "go1.20.0",
+// keep their original order. Invalid versions compare equal to each other and
+// less than all valid versions, so they are sorted to the front of the slice,
+// in their original order.
+func Sort(versions []string) {
+	slices.SortStableFunc(versions, Compare)
+}
+
+// SortVersions sorts a slice of parsed versions in increasing order,
+// compared using [Version.Compare].
+// Like Sort, it is stable, and zero Versions are sorted to the front.
+func SortVersions(versions []Version) {
+	slices.SortStableFunc(versions, Version.Compare)
+}
@@ -0,0 +1,51 @@
+package gover
+
+import (
+	"reflect"
+	"testing"
+)
+
+var sortTests = []struct {
+	in  []string
+	out []string
+}{
+	{nil, nil},
+	{
+		[]string{"go1.22", "go1.21.0", "go1.9", "go1.21rc1", "go1.21", "go1.10"},
+		[]string{"go1.9", "go1.10", "go1.21", "go1.21rc1", "go1.21.0", "go1.22"},
+	},
+	{
+		[]string{"go1.20.0", "bad", "go1.19rc1", "go1.20", "", "1.18"},
+		[]string{"bad", "", "1.18", "go1.19rc1", "go1.20.0", "go1.20"},
+	},
+	{
+		[]string{"go1.9.2", "go1.9.2rc2", "go1.9.1", "go1.9.2beta1"},
+		[]string{"go1.9.1", "go1.9.2beta1", "go1.9.2rc2", "go1.9.2"},
+	},
+}
+
+func TestSort(t *testing.T) {
+	for _, tt := range sortTests {
+		out := append([]string(nil), tt.in...)
+		Sort(out)
+		if !reflect.DeepEqual(out, tt.out) {
+			t.Errorf("Sort(%q) = %q, want %q", tt.in, out, tt.out)
+		}
+	}
+}
+
+func TestSortVersions(t *testing.T) {
+	for _, tt := range sortTests {
+		var out, want []Version
+		for _, x := range tt.in {
+			out = append(out, parse(stripGo(x)))
+		}
+		for _, x := range tt.out {
+			want = append(want, parse(stripGo(x)))
+		}
+		SortVersions(out)
+		if !reflect.DeepEqual(out, want) {
+			t.Errorf("SortVersions(%q) = %+v, want %+v", tt.in, out, want)
+		}
+	}
+}
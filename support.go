@@ -0,0 +1,193 @@
+package gover
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PartitionBySupport splits versions into those whose language version is
+// among the keep most recent language versions up to and including
+// latestLang, and those that are not. Both lists preserve the order of
+// versions. Invalid versions are always unsupported.
+// For example, with latestLang "go1.22" and keep 2, "go1.21.5" and
+// "go1.22rc1" are supported, while "go1.20.14" and "go1.23.0" are not.
+func PartitionBySupport(versions []string, latestLang string, keep int) (supported, unsupported []string) {
+	for _, x := range versions {
+		if IsValid(x) && inSupportWindow(Lang(x), latestLang, keep) {
+			supported = append(supported, x)
+		} else {
+			unsupported = append(unsupported, x)
+		}
+	}
+	return supported, unsupported
+}
+
+// inSupportWindow reports whether the language version lang is one of the
+// keep most recent language versions up to and including latestLang.
+func inSupportWindow(lang, latestLang string, keep int) bool {
+	latest := parse(stripGo(latestLang))
+	if latest == (Version{}) || keep <= 0 {
+		return false
+	}
+	v := parse(stripGo(lang))
+	if v == (Version{}) || v.Major != latest.Major || CmpInt(v.Minor, latest.Minor) > 0 {
+		return false
+	}
+	oldest := latest.Minor
+	for range keep - 1 {
+		if oldest == "0" {
+			break
+		}
+		oldest = DecInt(oldest)
+	}
+	return CmpInt(v.Minor, oldest) >= 0
+}
+
+// supportedSeries is the number of language versions that receive fixes:
+// per the Go release policy, each major release is supported until
+// there are two newer major releases.
+const supportedSeries = 2
+
+// SupportedLangVersions returns the language versions that currently
+// receive security and bug fixes under Go's release policy,
+// the two most recent ones, in increasing order.
+// For example, after the release of go1.23.0, SupportedLangVersions
+// returns ["go1.22", "go1.23"].
+// The newest language version is the later of the newest one in the
+// release table embedded in the package and the newest one whose
+// expected release date, as computed by ExpectedRelease, has passed.
+// Client.SupportedLangVersions uses the download server's list instead
+// of the embedded table.
+func SupportedLangVersions() []string {
+	return supportedLangs(currentLang(latestReleasedLang(), time.Now()))
+}
+
+// IsSupported reports whether the version v belongs to one of the
+// language versions returned by SupportedLangVersions.
+// Versions newer than the newest released language version are
+// assumed to be supported.
+// Invalid versions are never supported.
+func IsSupported(v string) bool {
+	return isSupported(v, latestReleasedLang(), time.Now())
+}
+
+// SupportedLangVersions is like the top-level SupportedLangVersions
+// but takes the newest released language version from the releases
+// listed by the download server, as returned by c.List.
+func (c *Client) SupportedLangVersions(ctx context.Context) ([]string, error) {
+	latest, err := c.latestReleasedLang(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return supportedLangs(currentLang(latest, time.Now())), nil
+}
+
+// IsSupported is like the top-level IsSupported but takes the newest
+// released language version from the releases listed by the download
+// server, as returned by c.List.
+func (c *Client) IsSupported(ctx context.Context, v string) (bool, error) {
+	latest, err := c.latestReleasedLang(ctx)
+	if err != nil {
+		return false, err
+	}
+	return isSupported(v, latest, time.Now()), nil
+}
+
+// isSupported reports whether v is supported at time now
+// when latest is the newest known released language version.
+func isSupported(v, latest string, now time.Time) bool {
+	if !IsValid(v) {
+		return false
+	}
+	latest = currentLang(latest, now)
+	lang := Lang(v)
+	if latest == "" || Compare(lang, latest) > 0 {
+		return true
+	}
+	return inSupportWindow(lang, latest, supportedSeries)
+}
+
+// currentLang returns the newest language version released at time now:
+// latest, or a later language version whose expected release date
+// is at or before now.
+func currentLang(latest string, now time.Time) string {
+	if latest == "" {
+		return ""
+	}
+	for {
+		next := NextLang(latest)
+		if date, ok := ExpectedRelease(next); !ok || date.After(now) {
+			return latest
+		}
+		latest = next
+	}
+}
+
+// latestReleasedLang returns the language version of the newest release
+// in the embedded release table.
+func latestReleasedLang() string {
+	table := releaseTable()
+	for i := len(table) - 1; i >= 0; i-- {
+		if table[i].stable {
+			return Lang(table[i].version)
+		}
+	}
+	return ""
+}
+
+// latestReleasedLang returns the language version of the newest stable
+// release listed by the download server.
+func (c *Client) latestReleasedLang(ctx context.Context) (string, error) {
+	list, err := c.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	var latest string
+	for _, r := range list {
+		if r.Stable && IsValid(r.Version) && (latest == "" || Compare(Lang(r.Version), latest) > 0) {
+			latest = Lang(r.Version)
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no stable releases listed")
+	}
+	return latest, nil
+}
+
+// supportedLangs returns the supported language versions
+// when latestLang is the newest released language version.
+func supportedLangs(latestLang string) []string {
+	oldest := Prev(latestLang)
+	if oldest == "" {
+		oldest = latestLang
+	}
+	var langs []string
+	for lang := range Langs(oldest, latestLang) {
+		if inSupportWindow(lang, latestLang, supportedSeries) {
+			langs = append(langs, lang)
+		}
+	}
+	return langs
+}
+
+// SupportWindow returns the time during which the language version lang,
+// such as "go1.21", receives fixes under Go's release policy:
+// from the release of its first release until the release of the first
+// release two language versions later, when it is no longer supported.
+// For example, go1.21 was released on 2023-08-08 and stopped being supported
+// with the release of go1.23.0 on 2024-08-13.
+//
+// Dates come from the release table embedded in the package.
+// Dates of releases that have not happened yet, according to that table,
+// are estimated from the release schedule, as by ExpectedRelease.
+// If lang is invalid, SupportWindow returns zero times.
+func SupportWindow(lang string) (start, end time.Time) {
+	lang = Lang(lang)
+	if lang == "" {
+		return time.Time{}, time.Time{}
+	}
+	start, _ = ExpectedRelease(lang)
+	end, _ = ExpectedRelease(NextLang(NextLang(lang)))
+	return start, end
+}
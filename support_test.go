@@ -0,0 +1,191 @@
+package gover
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var partitionBySupportTests = []struct {
+	versions    []string
+	latestLang  string
+	keep        int
+	supported   []string
+	unsupported []string
+}{
+	{
+		[]string{"go1.20.14", "go1.21.0", "go1.22.3", "go1.21rc2", "bad", "go1.20", "go1.22"},
+		"go1.22", 2,
+		[]string{"go1.21.0", "go1.22.3", "go1.21rc2", "go1.22"},
+		[]string{"go1.20.14", "bad", "go1.20"},
+	},
+	{
+		[]string{"go1.23.0", "go1.22.1", "go1.19.13"},
+		"go1.22", 1,
+		[]string{"go1.22.1"},
+		[]string{"go1.23.0", "go1.19.13"},
+	},
+	{
+		[]string{"go1.1", "go1", "go2.0.0"},
+		"go1.1", 5,
+		[]string{"go1.1", "go1"},
+		[]string{"go2.0.0"},
+	},
+	{
+		[]string{"go1.22.1"},
+		"go1.22", 0,
+		nil,
+		[]string{"go1.22.1"},
+	},
+	{
+		[]string{"go1.22.1"},
+		"bad", 2,
+		nil,
+		[]string{"go1.22.1"},
+	},
+}
+
+func TestPartitionBySupport(t *testing.T) {
+	for _, tt := range partitionBySupportTests {
+		supported, unsupported := PartitionBySupport(tt.versions, tt.latestLang, tt.keep)
+		if !reflect.DeepEqual(supported, tt.supported) || !reflect.DeepEqual(unsupported, tt.unsupported) {
+			t.Errorf("PartitionBySupport(%q, %q, %d) = %q, %q, want %q, %q", tt.versions, tt.latestLang, tt.keep, supported, unsupported, tt.supported, tt.unsupported)
+		}
+	}
+}
+
+var supportedLangsTests = []struct {
+	latestLang string
+	out        []string
+}{
+	{"go1.23", []string{"go1.22", "go1.23"}},
+	{"go1.21", []string{"go1.20", "go1.21"}},
+	{"go1.1", []string{"go1", "go1.1"}},
+	{"go1", []string{"go1"}},
+	{"", nil},
+}
+
+func TestSupportedLangs(t *testing.T) {
+	for _, tt := range supportedLangsTests {
+		if out := supportedLangs(tt.latestLang); !reflect.DeepEqual(out, tt.out) {
+			t.Errorf("supportedLangs(%q) = %q, want %q", tt.latestLang, out, tt.out)
+		}
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	langs := SupportedLangVersions()
+	if len(langs) != 2 {
+		t.Fatalf("SupportedLangVersions() = %q, want two language versions", langs)
+	}
+	latest := langs[1]
+	if want := currentLang(latestReleasedLang(), time.Now()); latest != want {
+		t.Errorf("SupportedLangVersions() = %q, want latest %q", langs, want)
+	}
+	for _, v := range []string{latest, NextPatch(latest), langs[0], NextPatch(NextPatch(langs[0])), NextMinor(latest)} {
+		if !IsSupported(v) {
+			t.Errorf("IsSupported(%q) = false, want true", v)
+		}
+	}
+	for _, v := range []string{Prev(langs[0]), "go1.0.3", "bad"} {
+		if IsSupported(v) {
+			t.Errorf("IsSupported(%q) = true, want false", v)
+		}
+	}
+}
+
+func TestIsSupportedClock(t *testing.T) {
+	// Well past the end of the embedded release table,
+	// go1.27 is expected to have been released on 2026-08-11.
+	now := time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)
+	if lang := currentLang(latestReleasedLang(), now); lang != "go1.27" {
+		t.Fatalf("currentLang(%q, %v) = %q, want go1.27", latestReleasedLang(), now, lang)
+	}
+	if langs := supportedLangs(currentLang(latestReleasedLang(), now)); !reflect.DeepEqual(langs, []string{"go1.26", "go1.27"}) {
+		t.Errorf("supported languages at %v = %q, want [go1.26 go1.27]", now, langs)
+	}
+	for _, tt := range []struct {
+		v, latest string
+		out       bool
+	}{
+		{"go1.27.1", latestReleasedLang(), true},
+		{"go1.26.0", latestReleasedLang(), true},
+		{"go1.28rc1", latestReleasedLang(), true},
+		{"go1.24.5", latestReleasedLang(), false},
+		{"go1.25.3", latestReleasedLang(), false},
+		{"go1.29.0", "go1.29", true},
+		{"go1.27.1", "go1.29", false},
+		{"bad", "go1.29", false},
+	} {
+		if out := isSupported(tt.v, tt.latest, now); out != tt.out {
+			t.Errorf("isSupported(%q, %q, %v) = %v, want %v", tt.v, tt.latest, now, out, tt.out)
+		}
+	}
+
+	// A release on the scheduled date counts.
+	if lang := currentLang("go1.25", time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC)); lang != "go1.26" {
+		t.Errorf("currentLang on go1.26 release date = %q, want go1.26", lang)
+	}
+}
+
+func TestClientSupportedLangVersions(t *testing.T) {
+	c := newTestClient(t, dlIndex)
+	ctx := context.Background()
+	if lang, err := c.latestReleasedLang(ctx); lang != "go1.23" || err != nil {
+		t.Fatalf("latestReleasedLang() = %q, %v, want go1.23, nil", lang, err)
+	}
+	want := supportedLangs(currentLang("go1.23", time.Now()))
+	if langs, err := c.SupportedLangVersions(ctx); !reflect.DeepEqual(langs, want) || err != nil {
+		t.Errorf("SupportedLangVersions() = %q, %v, want %q, nil", langs, err, want)
+	}
+	for _, tt := range []struct {
+		v   string
+		out bool
+	}{
+		{want[1], true},
+		{want[0], true},
+		{NextMinor(want[1]), true},
+		{"go1.22.3", false},
+		{"bad", false},
+	} {
+		if out, err := c.IsSupported(ctx, tt.v); out != tt.out || err != nil {
+			t.Errorf("IsSupported(%q) = %v, %v, want %v, nil", tt.v, out, err, tt.out)
+		}
+	}
+
+	c = newTestClient(t, `[{"version": "go1.24rc1", "stable": false, "files": []}]`)
+	if langs, err := c.SupportedLangVersions(ctx); err == nil {
+		t.Errorf("SupportedLangVersions() with no stable releases = %q, nil, want error", langs)
+	}
+}
+
+var supportWindowTests = []struct {
+	lang       string
+	start, end string
+}{
+	{"go1.21", "2023-08-08", "2024-08-13"},
+	{"go1.21.5", "2023-08-08", "2024-08-13"},
+	{"go1.20rc1", "2023-02-01", "2024-02-06"},
+	{"go1.22", "2024-02-06", "2025-02-11"},
+	{"go1", "2012-03-28", "2013-12-01"},
+	{"bad", "0001-01-01", "0001-01-01"},
+}
+
+func TestSupportWindow(t *testing.T) {
+	for _, tt := range supportWindowTests {
+		start, end := SupportWindow(tt.lang)
+		if s, e := start.Format(time.DateOnly), end.Format(time.DateOnly); s != tt.start || e != tt.end {
+			t.Errorf("SupportWindow(%q) = %s, %s, want %s, %s", tt.lang, s, e, tt.start, tt.end)
+		}
+	}
+
+	// Windows ending after the latest known release are estimated.
+	latest := latestReleasedLang()
+	released, _ := ReleaseDate(latest)
+	start, end := SupportWindow(latest)
+	want, _ := ExpectedRelease(NextLang(NextLang(latest)))
+	if !start.Equal(released) || !end.Equal(want) || want.IsZero() {
+		t.Errorf("SupportWindow(%q) = %v, %v, want %v, %v", latest, start, end, released, want)
+	}
+}
@@ -0,0 +1,345 @@
+package gover
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ResolveGOTOOLCHAIN returns the name of the toolchain that the go command
+// runs, following the rules described at https://go.dev/doc/toolchain#select.
+// env is the value of the GOTOOLCHAIN environment variable,
+// modToolchain and modGo are the toolchain and go directives of the main
+// module's go.mod (or go.work) file, or empty if absent, and local is the
+// version of the locally installed toolchain. All versions use the "go" prefix,
+// as in "go1.21.3".
+//
+// The precedence is:
+//
+//   - GOTOOLCHAIN=local always selects the local toolchain.
+//   - GOTOOLCHAIN=<name>, such as "go1.21.3", selects that toolchain,
+//     even if it is older than the local toolchain.
+//   - GOTOOLCHAIN=auto (also used when env is empty) or GOTOOLCHAIN=path
+//     starts from the local toolchain, and GOTOOLCHAIN=<name>+auto or
+//     <name>+path starts from <name>. The toolchain directive replaces the
+//     starting toolchain if it is newer, unless it is "default".
+//     Then, if the go directive is newer still, the go directive's version
+//     is selected, using the first release "go1.N.0" in place of a
+//     language version "go1.N" for Go 1.21 and later.
+//
+// ResolveGOTOOLCHAIN is a string form of [Select].
+func ResolveGOTOOLCHAIN(env, modToolchain, modGo, local string) (string, error) {
+	lv, err := Parse(local)
+	if err != nil {
+		return "", fmt.Errorf("invalid local toolchain version %q", local)
+	}
+	tc, err := ParseToolchain(env)
+	if err != nil {
+		return "", err
+	}
+	d, err := Select(lv, tc, modGo, modToolchain)
+	if err != nil {
+		return "", err
+	}
+	return d.Toolchain, nil
+}
+
+// An Action is the way in which the go command obtains the toolchain it runs.
+type Action int
+
+const (
+	UseLocal       Action = iota // run the local toolchain
+	SwitchPath                   // run another toolchain, found in PATH
+	SwitchDownload               // run another toolchain, found in PATH or else downloaded
+)
+
+var actionNames = [...]string{
+	UseLocal:       "local",
+	SwitchPath:     "path",
+	SwitchDownload: "download",
+}
+
+// String returns "local", "path", or "download".
+func (a Action) String() string {
+	if a < 0 || int(a) >= len(actionNames) {
+		return fmt.Sprintf("Action(%d)", int(a))
+	}
+	return actionNames[a]
+}
+
+// A Decision is the outcome of the go command's toolchain selection.
+type Decision struct {
+	Toolchain string // name of the toolchain to run, such as "go1.22.3"
+	Action    Action // how the toolchain is obtained
+	Reason    string // why it was selected, such as "go directive requires go1.22.3"
+}
+
+// Select predicts which toolchain the go command runs, following the rules
+// described at https://go.dev/doc/toolchain#select and summarized above
+// for ResolveGOTOOLCHAIN, and how it obtains that toolchain.
+// local is the version of the local toolchain, env is the GOTOOLCHAIN
+// setting, and goDirective and toolchainDirective are the go and toolchain
+// directives of the main module's go.mod (or go.work) file, or empty if
+// absent, using the "go" prefix, as in "go1.21" and "go1.21.3".
+//
+// A selected toolchain other than the local one is run from PATH if it
+// is there, as the golang.org/dl wrappers install it; otherwise, unless
+// GOTOOLCHAIN is "path" or "<name>+path", the go command downloads it.
+func Select(local Version, env Toolchain, goDirective, toolchainDirective string) (Decision, error) {
+	if local == (Version{}) {
+		return Decision{}, fmt.Errorf("missing local toolchain version")
+	}
+	d := Decision{Toolchain: local.Canonical(), Reason: "GOTOOLCHAIN=" + env.String()}
+	if !env.IsLocal() {
+		d.Toolchain = env.Version.Canonical()
+	}
+	if env.Mode != "" && toolchainDirective != "default" {
+		if toolchainDirective != "" {
+			if !IsValid(toolchainDirective) {
+				return Decision{}, fmt.Errorf("invalid toolchain directive %q", toolchainDirective)
+			}
+			if Compare(toolchainDirective, d.Toolchain) > 0 {
+				d.Toolchain = toolchainDirective
+				d.Reason = "toolchain directive requests " + toolchainDirective
+			}
+		}
+		if goDirective != "" {
+			if !IsValid(goDirective) {
+				return Decision{}, fmt.Errorf("invalid go directive %q", goDirective)
+			}
+			if Compare(goDirective, d.Toolchain) > 0 {
+				d.Toolchain = goDirective
+				// Go 1.21 and later have no release named by the language version alone.
+				if IsLang(goDirective) {
+					d.Toolchain += ".0"
+				}
+				d.Reason = "go directive requires " + goDirective
+			}
+		}
+	}
+	switch {
+	case d.Toolchain == local.Canonical():
+		d.Action = UseLocal
+	case env.Mode == "path":
+		d.Action = SwitchPath
+	default:
+		d.Action = SwitchDownload
+	}
+	return d, nil
+}
+
+// A Toolchain is a parsed value of the GOTOOLCHAIN environment variable,
+// which selects the Go toolchain that the go command runs.
+// See https://go.dev/doc/toolchain#GOTOOLCHAIN.
+type Toolchain struct {
+	// Version is the named toolchain, such as go1.21.0, or the zero
+	// Version for the local toolchain. If Mode is set, it is the minimum
+	// toolchain to run.
+	Version Version
+
+	// Mode is "auto" or "path" if the go command may switch to a newer
+	// toolchain requested by a go.mod or go.work file, downloading it
+	// or looking for it in PATH respectively, or "" if it never switches.
+	Mode string
+}
+
+// ParseToolchain parses a GOTOOLCHAIN setting, which is one of
+//
+//   - "local", to run the local toolchain;
+//   - a toolchain name, such as "go1.21.0", to run that toolchain;
+//   - "<name>+auto" or "<name>+path", such as "go1.21.0+auto",
+//     to run at least the toolchain <name>, or a newer one if required;
+//   - "auto" or "path", short for "local+auto" and "local+path".
+//
+// The empty string is parsed as "auto", the default setting.
+func ParseToolchain(s string) (Toolchain, error) {
+	if s == "" || s == "auto" || s == "path" {
+		return Toolchain{Mode: cmp.Or(s, "auto")}, nil
+	}
+	name, mode, plus := strings.Cut(s, "+")
+	if plus && mode != "auto" && mode != "path" {
+		return Toolchain{}, fmt.Errorf("invalid GOTOOLCHAIN %q: only version suffixes are +auto and +path", s)
+	}
+	if name == "local" {
+		return Toolchain{Mode: mode}, nil
+	}
+	v, err := Parse(name)
+	if err != nil {
+		return Toolchain{}, fmt.Errorf("invalid GOTOOLCHAIN %q: %v", s, err)
+	}
+	return Toolchain{Version: v, Mode: mode}, nil
+}
+
+// IsLocal reports whether t names the local toolchain.
+func (t Toolchain) IsLocal() bool {
+	return t.Version == Version{}
+}
+
+// String returns the GOTOOLCHAIN setting for t,
+// using the short forms "auto" and "path" where possible.
+func (t Toolchain) String() string {
+	if t.IsLocal() {
+		return cmp.Or(t.Mode, "local")
+	}
+	if t.Mode == "" {
+		return t.Version.Canonical()
+	}
+	return t.Version.Canonical() + "+" + t.Mode
+}
+
+// ToolchainModule is the path of the module through which the go command
+// downloads toolchains from the module proxy.
+const ToolchainModule = "golang.org/toolchain"
+
+// toolchainModulePrefix is the prefix of the versions of ToolchainModule.
+const toolchainModulePrefix = "v0.0.1-"
+
+// ToolchainModuleVersion returns the version of [ToolchainModule] holding
+// the toolchain name built for goos/goarch, as the go command requests it
+// from the module proxy. For example:
+//
+//	ToolchainModuleVersion("go1.21.0", "linux", "amd64") = "v0.0.1-go1.21.0.linux-amd64"
+//
+// Unlike the files on the download server, module versions use the
+// GOARCH value unchanged, so that 32-bit ARM Linux is "linux-arm".
+func ToolchainModuleVersion(name, goos, goarch string) (string, error) {
+	if !IsValid(name) {
+		return "", fmt.Errorf("invalid toolchain name %q", name)
+	}
+	if goos == "" || goarch == "" || strings.ContainsAny(goos+goarch, "-./") {
+		return "", fmt.Errorf("invalid GOOS/GOARCH %q/%q", goos, goarch)
+	}
+	return toolchainModulePrefix + name + "." + goos + "-" + goarch, nil
+}
+
+// ParseToolchainModuleVersion parses a version of [ToolchainModule],
+// such as "v0.0.1-go1.21.0.linux-amd64", returning the toolchain name
+// and the GOOS and GOARCH it was built for.
+// It is the inverse of [ToolchainModuleVersion].
+func ParseToolchainModuleVersion(v string) (name, goos, goarch string, err error) {
+	rest, ok := strings.CutPrefix(v, toolchainModulePrefix)
+	i := strings.LastIndexByte(rest, '.')
+	if !ok || i < 0 {
+		return "", "", "", fmt.Errorf("malformed toolchain module version %q", v)
+	}
+	name = rest[:i]
+	goos, goarch, ok = strings.Cut(rest[i+1:], "-")
+	if !ok || goos == "" || goarch == "" || strings.Contains(goarch, "-") || !IsValid(name) {
+		return "", "", "", fmt.Errorf("malformed toolchain module version %q", v)
+	}
+	return name, goos, goarch, nil
+}
+
+// A SuggestionKind is the change to a toolchain directive
+// recommended by [SuggestToolchain].
+type SuggestionKind int
+
+const (
+	KeepToolchain   SuggestionKind = iota // leave the toolchain directive as is
+	AddToolchain                          // add a toolchain directive
+	UpdateToolchain                       // change the existing toolchain directive
+)
+
+var suggestionKindNames = [...]string{
+	KeepToolchain:   "keep",
+	AddToolchain:    "add",
+	UpdateToolchain: "update",
+}
+
+// String returns "keep", "add", or "update".
+func (k SuggestionKind) String() string {
+	if k < 0 || int(k) >= len(suggestionKindNames) {
+		return fmt.Sprintf("SuggestionKind(%d)", int(k))
+	}
+	return suggestionKindNames[k]
+}
+
+// MarshalText returns the text of k.String(),
+// so that the kind appears by name in JSON.
+func (k SuggestionKind) MarshalText() ([]byte, error) {
+	if k < 0 || int(k) >= len(suggestionKindNames) {
+		return nil, fmt.Errorf("invalid SuggestionKind %d", int(k))
+	}
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText sets k to the kind named by text, as returned by MarshalText.
+func (k *SuggestionKind) UnmarshalText(text []byte) error {
+	for i, name := range suggestionKindNames {
+		if string(text) == name {
+			*k = SuggestionKind(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid SuggestionKind %q", text)
+}
+
+// A Suggestion is a recommended change to a module's toolchain directive,
+// in a form suited to automated tools, which can encode it as JSON.
+type Suggestion struct {
+	Kind      SuggestionKind `json:"kind"`
+	Toolchain string         `json:"toolchain,omitempty"` // directive to write, such as "go1.22.5", unless Kind is KeepToolchain
+	Current   string         `json:"current,omitempty"`   // existing toolchain directive, if any
+	Reason    string         `json:"reason"`
+}
+
+// SuggestToolchain recommends whether a module whose requirements need
+// Go version required, such as "go1.22.1" as computed by [Client.RequiredGo],
+// should add or update its toolchain directive, currently toolchainDirective
+// or empty if absent, so that developers whose local toolchain is local
+// switch to a toolchain that can build it.
+// The suggested toolchain is the latest release among releases of the
+// language version of required, and is never older than required itself.
+//
+// No change is suggested if the current toolchain directive already
+// satisfies required, or if local, which may be a development version in
+// the form accepted by ParseRuntime, can build the module itself.
+func SuggestToolchain(local, required, toolchainDirective string, releases []string) (Suggestion, error) {
+	if !IsValid(required) {
+		return Suggestion{}, fmt.Errorf("invalid version %q", required)
+	}
+	if _, err := ParseRuntime(local); err != nil {
+		return Suggestion{}, err
+	}
+	s := Suggestion{Current: toolchainDirective}
+	if toolchainDirective != "" && toolchainDirective != "default" {
+		if !IsValid(toolchainDirective) {
+			return Suggestion{}, fmt.Errorf("invalid toolchain directive %q", toolchainDirective)
+		}
+		if Compare(toolchainDirective, required) >= 0 {
+			s.Reason = "toolchain directive " + toolchainDirective + " satisfies " + required
+			return s, nil
+		}
+	}
+	if Supports(local, required) {
+		s.Reason = "local toolchain " + local + " satisfies " + required
+		return s, nil
+	}
+
+	lang := Lang(required)
+	for _, v := range releases {
+		if IsRelease(v) && Lang(v) == lang && Compare(v, required) >= 0 && (s.Toolchain == "" || Compare(v, s.Toolchain) > 0) {
+			s.Toolchain = v
+		}
+	}
+	if s.Toolchain == "" {
+		return Suggestion{}, fmt.Errorf("no release of %s satisfies %s", lang, required)
+	}
+	s.Kind = AddToolchain
+	if toolchainDirective != "" {
+		s.Kind = UpdateToolchain
+	}
+	s.Reason = "local toolchain " + local + " cannot build a module requiring " + required
+	return s, nil
+}
+
+// SuggestToolchain is like the package-level [SuggestToolchain],
+// choosing among the releases listed by the download server.
+func (c *Client) SuggestToolchain(ctx context.Context, local, required, toolchainDirective string) (Suggestion, error) {
+	vs, err := c.versions(ctx)
+	if err != nil {
+		return Suggestion{}, err
+	}
+	return SuggestToolchain(local, required, toolchainDirective, vs)
+}
@@ -0,0 +1,269 @@
+package gover
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+var resolveGOTOOLCHAINTests = []struct {
+	env, modToolchain, modGo, local string
+	out                             string
+	err                             bool
+}{
+	// local always wins.
+	{"local", "go1.23.0", "go1.23", "go1.21.0", "go1.21.0", false},
+
+	// An explicit version wins, even below local.
+	{"go1.22.4", "go1.23.0", "go1.23", "go1.21.0", "go1.22.4", false},
+	{"go1.20.1", "", "go1.21", "go1.22.0", "go1.20.1", false},
+
+	// auto: newest of local, toolchain directive, go directive.
+	{"auto", "", "", "go1.21.0", "go1.21.0", false},
+	{"", "", "go1.20", "go1.21.0", "go1.21.0", false},
+	{"auto", "go1.22.3", "go1.21", "go1.21.0", "go1.22.3", false},
+	{"auto", "go1.20.1", "go1.21", "go1.22.0", "go1.22.0", false},
+	{"auto", "go1.22.3", "go1.23.1", "go1.21.0", "go1.23.1", false},
+	{"auto", "", "go1.22", "go1.21.0", "go1.22.0", false},
+	{"path", "go1.21.5", "go1.21", "go1.21.0", "go1.21.5", false},
+	{"auto", "default", "go1.23", "go1.21.0", "go1.21.0", false},
+
+	// <name>+auto: newest of name, toolchain directive, go directive.
+	{"go1.22.1+auto", "", "go1.21", "go1.21.0", "go1.22.1", false},
+	{"go1.22.1+auto", "go1.22.5", "go1.21", "go1.21.0", "go1.22.5", false},
+	{"go1.22.1+path", "", "go1.23", "go1.21.0", "go1.23.0", false},
+	{"go1.20.1+auto", "", "go1.20", "go1.21.0", "go1.20.1", false},
+	{"go1.22.1+auto", "default", "go1.23", "go1.21.0", "go1.22.1", false},
+
+	// Invalid inputs.
+	{"go1.22.1+bad", "", "", "go1.21.0", "", true},
+	{"bad", "", "", "go1.21.0", "", true},
+	{"1.22.1", "", "", "go1.21.0", "", true},
+	{"auto", "bad", "", "go1.21.0", "", true},
+	{"auto", "", "1.22", "go1.21.0", "", true},
+	{"auto", "", "", "", "", true},
+}
+
+func TestResolveGOTOOLCHAIN(t *testing.T) {
+	for _, tt := range resolveGOTOOLCHAINTests {
+		out, err := ResolveGOTOOLCHAIN(tt.env, tt.modToolchain, tt.modGo, tt.local)
+		if out != tt.out || (err != nil) != tt.err {
+			t.Errorf("ResolveGOTOOLCHAIN(%q, %q, %q, %q) = %q, %v, want %q, err=%v", tt.env, tt.modToolchain, tt.modGo, tt.local, out, err, tt.out, tt.err)
+		}
+	}
+}
+
+var parseToolchainTests = []struct {
+	in      string
+	version string // Canonical of Version
+	mode    string
+	out     string // String, or "" for error
+}{
+	{"", "", "auto", "auto"},
+	{"auto", "", "auto", "auto"},
+	{"path", "", "path", "path"},
+	{"local", "", "", "local"},
+	{"local+auto", "", "auto", "auto"},
+	{"local+path", "", "path", "path"},
+	{"go1.21.0", "go1.21.0", "", "go1.21.0"},
+	{"go1.20", "go1.20", "", "go1.20"},
+	{"go1.22rc1", "go1.22rc1", "", "go1.22rc1"},
+	{"go1.21.0+auto", "go1.21.0", "auto", "go1.21.0+auto"},
+	{"go1.21.0+path", "go1.21.0", "path", "go1.21.0+path"},
+	{"go1.21.0-bigcorp+auto", "go1.21.0-bigcorp", "auto", "go1.21.0-bigcorp+auto"},
+	{"go1.21.0+local", "", "", ""},
+	{"go1.21.0+", "", "", ""},
+	{"local+", "", "", ""},
+	{"+auto", "", "", ""},
+	{"1.21.0", "", "", ""},
+	{"default", "", "", ""},
+	{"Auto", "", "", ""},
+}
+
+func TestParseToolchain(t *testing.T) {
+	for _, tt := range parseToolchainTests {
+		tc, err := ParseToolchain(tt.in)
+		if tt.out == "" {
+			if err == nil {
+				t.Errorf("ParseToolchain(%q) = %+v, want error", tt.in, tc)
+			}
+			continue
+		}
+		if err != nil || tc.Version.Canonical() != tt.version || tc.Mode != tt.mode || tc.String() != tt.out {
+			t.Errorf("ParseToolchain(%q) = %+v (%q), %v, want %s, %q (%q)", tt.in, tc, tc, err, tt.version, tt.mode, tt.out)
+		}
+		if tc.IsLocal() != (tt.version == "") {
+			t.Errorf("ParseToolchain(%q).IsLocal() = %v", tt.in, tc.IsLocal())
+		}
+	}
+}
+
+var selectTests = []struct {
+	local, env, goDirective, toolchainDirective string
+	toolchain                                   string
+	action                                      Action
+	reason                                      string
+}{
+	{"go1.21.0", "local", "go1.23", "go1.23.0", "go1.21.0", UseLocal, "GOTOOLCHAIN=local"},
+	{"go1.21.0", "go1.22.4", "go1.23", "", "go1.22.4", SwitchDownload, "GOTOOLCHAIN=go1.22.4"},
+	{"go1.22.4", "go1.22.4", "", "", "go1.22.4", UseLocal, "GOTOOLCHAIN=go1.22.4"},
+	{"go1.21.0", "auto", "go1.20", "", "go1.21.0", UseLocal, "GOTOOLCHAIN=auto"},
+	{"go1.21.0", "auto", "go1.21", "go1.22.3", "go1.22.3", SwitchDownload, "toolchain directive requests go1.22.3"},
+	{"go1.21.0", "auto", "go1.22", "", "go1.22.0", SwitchDownload, "go directive requires go1.22"},
+	{"go1.21.0", "auto", "go1.23.1", "go1.22.3", "go1.23.1", SwitchDownload, "go directive requires go1.23.1"},
+	{"go1.21.0", "path", "go1.21", "go1.21.5", "go1.21.5", SwitchPath, "toolchain directive requests go1.21.5"},
+	{"go1.21.0", "auto", "go1.23", "default", "go1.21.0", UseLocal, "GOTOOLCHAIN=auto"},
+	{"go1.21.0", "go1.22.1+auto", "go1.21", "", "go1.22.1", SwitchDownload, "GOTOOLCHAIN=go1.22.1+auto"},
+	{"go1.21.0", "go1.22.1+path", "go1.23", "", "go1.23.0", SwitchPath, "go directive requires go1.23"},
+	{"go1.22.0", "go1.20.1+auto", "go1.20", "", "go1.20.1", SwitchDownload, "GOTOOLCHAIN=go1.20.1+auto"},
+	{"go1.20", "auto", "go1.20", "", "go1.20", UseLocal, "GOTOOLCHAIN=auto"},
+}
+
+func TestSelect(t *testing.T) {
+	for _, tt := range selectTests {
+		env, err := ParseToolchain(tt.env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := Select(MustParse(tt.local), env, tt.goDirective, tt.toolchainDirective)
+		want := Decision{tt.toolchain, tt.action, tt.reason}
+		if d != want || err != nil {
+			t.Errorf("Select(%s, %s, %q, %q) = %+v, %v, want %+v", tt.local, tt.env, tt.goDirective, tt.toolchainDirective, d, err, want)
+		}
+	}
+
+	auto := Toolchain{Mode: "auto"}
+	for _, tt := range []struct{ goDirective, toolchainDirective string }{
+		{"1.22", ""},
+		{"", "bad"},
+	} {
+		if d, err := Select(MustParse("go1.21.0"), auto, tt.goDirective, tt.toolchainDirective); err == nil {
+			t.Errorf("Select(go1.21.0, auto, %q, %q) = %+v, want error", tt.goDirective, tt.toolchainDirective, d)
+		}
+	}
+	if d, err := Select(Version{}, auto, "", ""); err == nil {
+		t.Errorf("Select(zero Version) = %+v, want error", d)
+	}
+}
+
+func TestActionString(t *testing.T) {
+	for a, want := range map[Action]string{UseLocal: "local", SwitchPath: "path", SwitchDownload: "download", 7: "Action(7)"} {
+		if got := a.String(); got != want {
+			t.Errorf("Action(%d).String() = %q, want %q", int(a), got, want)
+		}
+	}
+}
+
+var suggestToolchainTests = []struct {
+	local, required, toolchainDirective string
+	kind                                SuggestionKind
+	toolchain                           string
+	err                                 bool
+}{
+	{"go1.23.0", "go1.22.1", "", KeepToolchain, "", false},
+	{"go1.22.1", "go1.22.1", "", KeepToolchain, "", false},
+	{"go1.21.5", "go1.22.1", "", AddToolchain, "go1.22.10", false},
+	{"go1.21.5", "go1.22", "", AddToolchain, "go1.22.10", false},
+	{"go1.21.5", "go1.22.1", "go1.22.0", UpdateToolchain, "go1.22.10", false},
+	{"go1.21.5", "go1.22.1", "default", UpdateToolchain, "go1.22.10", false},
+	{"go1.21.5", "go1.22.1", "go1.22.3", KeepToolchain, "", false},
+	{"go1.21.5", "go1.22.1", "go1.23.0", KeepToolchain, "", false},
+	{"devel go1.23-2b1f1e8 Tue Jul 2 18:27:20 2024 +0000", "go1.23.0", "", KeepToolchain, "", false},
+	{"go1.23.4", "go1.24rc1", "", KeepToolchain, "", true}, // no release of go1.24
+	{"go1.21.5", "go1.22.11", "", KeepToolchain, "", true},
+	{"go1.21.5", "1.22", "", KeepToolchain, "", true},
+	{"bogus", "go1.22", "", KeepToolchain, "", true},
+	{"go1.21.5", "go1.22", "1.22.3", KeepToolchain, "", true},
+}
+
+func TestSuggestToolchain(t *testing.T) {
+	releases := []string{"go1.24rc1", "go1.23.4", "go1.22.10", "go1.22.9", "go1.22.0", "go1.21.13"}
+	for _, tt := range suggestToolchainTests {
+		s, err := SuggestToolchain(tt.local, tt.required, tt.toolchainDirective, releases)
+		if err != nil {
+			if !tt.err {
+				t.Errorf("SuggestToolchain(%q, %q, %q): %v", tt.local, tt.required, tt.toolchainDirective, err)
+			}
+			continue
+		}
+		if tt.err || s.Kind != tt.kind || s.Toolchain != tt.toolchain || s.Current != tt.toolchainDirective || s.Reason == "" {
+			t.Errorf("SuggestToolchain(%q, %q, %q) = %+v, want kind %v, toolchain %q, err=%v", tt.local, tt.required, tt.toolchainDirective, s, tt.kind, tt.toolchain, tt.err)
+		}
+	}
+}
+
+func TestSuggestionJSON(t *testing.T) {
+	s, err := SuggestToolchain("go1.21.5", "go1.22.1", "go1.22.0", []string{"go1.22.10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"kind":"update","toolchain":"go1.22.10","current":"go1.22.0","reason":"local toolchain go1.21.5 cannot build a module requiring go1.22.1"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal(%+v) = %s, want %s", s, data, want)
+	}
+	var back Suggestion
+	if err := json.Unmarshal(data, &back); err != nil || back != s {
+		t.Errorf("json.Unmarshal(%s) = %+v, %v, want %+v", data, back, err, s)
+	}
+	if err := json.Unmarshal([]byte(`{"kind":"delete"}`), &back); err == nil {
+		t.Errorf("json.Unmarshal with unknown kind succeeded")
+	}
+	if _, err := SuggestionKind(7).MarshalText(); err == nil || SuggestionKind(7).String() != "SuggestionKind(7)" {
+		t.Errorf("SuggestionKind(7) marshaled without error")
+	}
+}
+
+func TestClientSuggestToolchain(t *testing.T) {
+	c := newTestClient(t, dlIndex)
+	s, err := c.SuggestToolchain(context.Background(), "go1.21.5", "go1.23.1", "")
+	if err != nil || s.Kind != AddToolchain || s.Toolchain != "go1.23.4" {
+		t.Errorf("SuggestToolchain = %+v, %v, want add go1.23.4", s, err)
+	}
+}
+
+var toolchainModuleVersionTests = []struct {
+	name, goos, goarch string
+	out                string
+}{
+	{"go1.21.0", "linux", "amd64", "v0.0.1-go1.21.0.linux-amd64"},
+	{"go1.22rc1", "darwin", "arm64", "v0.0.1-go1.22rc1.darwin-arm64"},
+	{"go1.21.3", "linux", "arm", "v0.0.1-go1.21.3.linux-arm"},
+	{"go1.21.3-bigcorp", "windows", "amd64", "v0.0.1-go1.21.3-bigcorp.windows-amd64"},
+	{"1.21.0", "linux", "amd64", ""},
+	{"go1.21.0", "", "amd64", ""},
+	{"go1.21.0", "linux", "", ""},
+	{"go1.21.0", "linux-x", "amd64", ""},
+}
+
+func TestToolchainModuleVersion(t *testing.T) {
+	for _, tt := range toolchainModuleVersionTests {
+		out, err := ToolchainModuleVersion(tt.name, tt.goos, tt.goarch)
+		if out != tt.out || (err != nil) != (tt.out == "") {
+			t.Errorf("ToolchainModuleVersion(%q, %q, %q) = %q, %v, want %q", tt.name, tt.goos, tt.goarch, out, err, tt.out)
+		}
+		if tt.out == "" {
+			continue
+		}
+		name, goos, goarch, err := ParseToolchainModuleVersion(out)
+		if name != tt.name || goos != tt.goos || goarch != tt.goarch || err != nil {
+			t.Errorf("ParseToolchainModuleVersion(%q) = %q, %q, %q, %v, want %q, %q, %q", out, name, goos, goarch, err, tt.name, tt.goos, tt.goarch)
+		}
+	}
+	for _, bad := range []string{
+		"go1.21.0.linux-amd64",
+		"v0.0.1-go1.21.0",
+		"v0.0.1-go1.21.0.linux",
+		"v0.0.1-go1.21.0.linux-",
+		"v0.0.1-go1.21.0.linux-amd64-v3",
+		"v0.0.1-1.21.0.linux-amd64",
+		"v0.0.2-go1.21.0.linux-amd64",
+	} {
+		if name, goos, goarch, err := ParseToolchainModuleVersion(bad); err == nil {
+			t.Errorf("ParseToolchainModuleVersion(%q) = %q, %q, %q, nil, want error", bad, name, goos, goarch)
+		}
+	}
+}
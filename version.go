@@ -0,0 +1,194 @@
+package gover
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// New returns the release version major.minor.patch.
+// For example, New(1, 21, 3) is go1.21.3, and New(1, 20, 0) is go1.20.
+// New panics if any argument is negative.
+func New(major, minor, patch int) Version {
+	if major < 0 || minor < 0 || patch < 0 {
+		panic(fmt.Sprintf("gover: New(%d, %d, %d): negative version number", major, minor, patch))
+	}
+	return Version{Major: strconv.Itoa(major), Minor: strconv.Itoa(minor), Patch: strconv.Itoa(patch)}
+}
+
+// FromInts returns the version with the given major, minor, and patch
+// numbers, of which the minor and patch numbers may be omitted.
+// Omitting the patch number gives a language version, such as FromInts(1, 22)
+// for go1.22, exactly as Parse would parse the corresponding string:
+// before Go 1.21, that is the same as the series' first release.
+// FromInts returns an error if any number is negative or if it is given
+// other than one to three numbers.
+func FromInts(nums ...int) (Version, error) {
+	if len(nums) < 1 || len(nums) > 3 {
+		return Version{}, fmt.Errorf("gover: FromInts: need 1 to 3 numbers, have %d", len(nums))
+	}
+	s := "go"
+	for i, n := range nums {
+		if n < 0 {
+			return Version{}, fmt.Errorf("gover: FromInts: negative version number %d", n)
+		}
+		if i > 0 {
+			s += "."
+		}
+		s += strconv.Itoa(n)
+	}
+	return Parse(s)
+}
+
+// WithAlpha returns the nth alpha prerelease of the release v.
+// See [Version.WithRC] for details.
+func (v Version) WithAlpha(n int) Version { return v.withPre("alpha", n) }
+
+// WithBeta returns the nth beta prerelease of the release v.
+// See [Version.WithRC] for details.
+func (v Version) WithBeta(n int) Version { return v.withPre("beta", n) }
+
+// WithRC returns the nth release candidate of the release v.
+// The release candidates of a series' first release omit the patch number,
+// so New(1, 22, 0).WithRC(2) is go1.22rc2, while New(1, 9, 2).WithRC(1) is go1.9.2rc1.
+// WithAlpha, WithBeta, and WithRC panic if n is not positive.
+func (v Version) WithRC(n int) Version { return v.withPre("rc", n) }
+
+func (v Version) withPre(kind string, n int) Version {
+	if n <= 0 {
+		panic(fmt.Sprintf("gover: invalid %s number %d: prerelease numbers start at 1", kind, n))
+	}
+	if v.Patch == "0" {
+		v.Patch = ""
+	}
+	v.Kind = kind
+	v.Pre = strconv.Itoa(n)
+	return v
+}
+
+// Ints returns the numeric components of v. Components that are not set,
+// such as the patch number of a language version, are returned as 0.
+// If a component does not fit in an int, Ints returns an error.
+func (v Version) Ints() (major, minor, patch, pre int, err error) {
+	nums := [4]int{}
+	for i, s := range [4]string{v.Major, v.Minor, v.Patch, v.Pre} {
+		if s == "" {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("gover: version %v: number %s out of range", v, s)
+		}
+		nums[i] = n
+	}
+	return nums[0], nums[1], nums[2], nums[3], nil
+}
+
+// A Level is a precision to which a version can be truncated.
+type Level int
+
+const (
+	Major Level = iota // the major version, as in "go1"
+	Minor              // the language version, as in "go1.21"
+	Patch              // the release, as in "go1.21.3", without any prerelease
+)
+
+// Truncate returns the version v truncated to the given level.
+// Truncate(v, Minor) is the same as Lang(v).
+// For example:
+//
+//	Truncate("go1.21.3", Major) = "go1"
+//	Truncate("go1.21.3", Minor) = "go1.21"
+//	Truncate("go1.21.3-bigcorp", Patch) = "go1.21.3"
+//	Truncate("go1.9.2rc2", Patch) = "go1.9.2"
+//	Truncate("go1.21rc2", Patch) = "go1.21"
+//
+// If v is invalid or level is unknown, Truncate returns the empty string.
+func Truncate(v string, level Level) string {
+	x := parse(stripGo(v))
+	if x == (Version{}) {
+		return ""
+	}
+	switch level {
+	case Major:
+		return "go" + x.Major
+	case Minor:
+		return x.Lang().Canonical()
+	case Patch:
+		return Version{Major: x.Major, Minor: x.Minor, Patch: x.Patch}.Canonical()
+	}
+	return ""
+}
+
+// WithPatch returns a copy of v with the patch number set to patch,
+// which must be a decimal number without leading zeros, or "" to remove it.
+// As with Parse, removing the patch number of a release before Go 1.21
+// leaves the implied patch number 0, so that
+// MustParse("go1.20.3").WithPatch("") is go1.20, the same as MustParse("go1.20").
+// For example, MustParse("go1.21.3").WithPatch("5") is go1.21.5.
+// Like New and [Version.WithRC], WithPatch is meant for building versions
+// from known components and panics if patch is malformed;
+// use Parse to check untrusted input.
+func (v Version) WithPatch(patch string) Version {
+	if patch != "" && !isDecimal(patch) {
+		panic(fmt.Sprintf("gover: WithPatch(%q): invalid patch number", patch))
+	}
+	v.Patch = patch
+	return v.fillPatch()
+}
+
+// WithKind returns a copy of v with the prerelease kind set to kind,
+// which must be a lower-case word such as "rc", or "" to remove it.
+// As with [Version.WithRC], setting a kind on a version with patch number 0
+// removes the patch number, since the prereleases of a series' first release
+// omit it:
+//
+//	MustParse("go1.20").WithKind("rc").WithPre("2") // go1.20rc2
+//	MustParse("go1.9.2").WithKind("rc").WithPre("1") // go1.9.2rc1
+//
+// Removing the kind also removes the prerelease number and,
+// as with Parse, restores the implied patch number 0 of a release before Go 1.21.
+// Like [Version.WithPatch], WithKind panics if kind is malformed.
+func (v Version) WithKind(kind string) Version {
+	for i := 0; i < len(kind); i++ {
+		if kind[i] < 'a' || 'z' < kind[i] {
+			panic(fmt.Sprintf("gover: WithKind(%q): invalid prerelease kind", kind))
+		}
+	}
+	v.Kind = kind
+	if kind == "" {
+		v.Pre = ""
+	} else if v.Patch == "0" {
+		v.Patch = ""
+	}
+	return v.fillPatch()
+}
+
+// fillPatch returns v with the patch number 0 that Parse fills in
+// for releases before Go 1.21 that omit it.
+func (v Version) fillPatch() Version {
+	if v.Patch == "" && v.Kind == "" && v.Minor != "" && !UsesExplicitPatchZero(v.Minor) {
+		v.Patch = "0"
+	}
+	return v
+}
+
+// WithPre returns a copy of v with the prerelease number set to pre,
+// which must be a decimal number without leading zeros, or "" to remove it.
+// Like [Version.WithPatch], WithPre panics if pre is malformed
+// or if v has no prerelease kind.
+func (v Version) WithPre(pre string) Version {
+	if pre != "" && !isDecimal(pre) {
+		panic(fmt.Sprintf("gover: WithPre(%q): invalid prerelease number", pre))
+	}
+	if pre != "" && v.Kind == "" {
+		panic(fmt.Sprintf("gover: WithPre(%q): version %v has no prerelease kind", pre, v))
+	}
+	v.Pre = pre
+	return v
+}
+
+// isDecimal reports whether x is a decimal number without unnecessary leading zeros.
+func isDecimal(x string) bool {
+	_, rest, ok := cutInt(x)
+	return ok && rest == ""
+}
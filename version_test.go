@@ -0,0 +1,196 @@
+package gover
+
+import "testing"
+
+var newTests = []struct {
+	major, minor, patch int
+	out                 string
+}{
+	{1, 21, 3, "go1.21.3"},
+	{1, 21, 0, "go1.21.0"},
+	{1, 20, 0, "go1.20"},
+	{1, 9, 2, "go1.9.2"},
+	{1, 0, 0, "go1"},
+}
+
+func TestNew(t *testing.T) {
+	for _, tt := range newTests {
+		v := New(tt.major, tt.minor, tt.patch)
+		if out := v.Canonical(); out != tt.out {
+			t.Errorf("New(%d, %d, %d) = %s, want %s", tt.major, tt.minor, tt.patch, out, tt.out)
+		}
+		if v != MustParse(tt.out) {
+			t.Errorf("New(%d, %d, %d) = %+v, want %+v", tt.major, tt.minor, tt.patch, v, MustParse(tt.out))
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(1, -1, 0) did not panic")
+		}
+	}()
+	New(1, -1, 0)
+}
+
+var fromIntsTests = []struct {
+	in  []int
+	out string
+}{
+	{[]int{1}, "go1"},
+	{[]int{1, 22}, "go1.22"},
+	{[]int{1, 20}, "go1.20"},
+	{[]int{1, 21, 0}, "go1.21.0"},
+	{[]int{1, 9, 2}, "go1.9.2"},
+	{nil, ""},
+	{[]int{1, 2, 3, 4}, ""},
+	{[]int{1, -2}, ""},
+}
+
+func TestFromInts(t *testing.T) {
+	for _, tt := range fromIntsTests {
+		v, err := FromInts(tt.in...)
+		if tt.out == "" {
+			if err == nil {
+				t.Errorf("FromInts(%v) = %v, want error", tt.in, v)
+			}
+			continue
+		}
+		if want := MustParse(tt.out); err != nil || v != want {
+			t.Errorf("FromInts(%v) = %+v, %v, want %+v", tt.in, v, err, want)
+		}
+	}
+}
+
+func TestWithPrerelease(t *testing.T) {
+	for _, tt := range []struct {
+		v    Version
+		want string
+	}{
+		{New(1, 22, 0).WithRC(2), "go1.22rc2"},
+		{New(1, 22, 0).WithBeta(1), "go1.22beta1"},
+		{New(1, 20, 0).WithAlpha(1), "go1.20alpha1"},
+		{MustParse("go1.23").WithRC(1), "go1.23rc1"},
+		{New(1, 9, 2).WithRC(1), "go1.9.2rc1"},
+		{MustParse("go1.22rc1").WithRC(3), "go1.22rc3"},
+	} {
+		if out := tt.v.Canonical(); out != tt.want {
+			t.Errorf("got %s, want %s", out, tt.want)
+		}
+		if tt.v != MustParse(tt.want) {
+			t.Errorf("got %+v, want %+v", tt.v, MustParse(tt.want))
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("WithRC(0) did not panic")
+		}
+	}()
+	New(1, 22, 0).WithRC(0)
+}
+
+var intsTests = []struct {
+	in                       string
+	major, minor, patch, pre int
+	err                      bool
+}{
+	{"go1.21.3", 1, 21, 3, 0, false},
+	{"go1.21", 1, 21, 0, 0, false},
+	{"go1.22rc2", 1, 22, 0, 2, false},
+	{"go1.9.2rc2", 1, 9, 2, 2, false},
+	{"go1.20", 1, 20, 0, 0, false},
+	{"go1", 1, 0, 0, 0, false},
+	{"go1.99999999999999999999", 0, 0, 0, 0, true},
+	{"go1.2.3rc99999999999999999999", 0, 0, 0, 0, true},
+}
+
+func TestInts(t *testing.T) {
+	for _, tt := range intsTests {
+		major, minor, patch, pre, err := MustParse(tt.in).Ints()
+		if major != tt.major || minor != tt.minor || patch != tt.patch || pre != tt.pre || (err != nil) != tt.err {
+			t.Errorf("Parse(%q).Ints() = %d, %d, %d, %d, %v, want %d, %d, %d, %d, err=%v", tt.in, major, minor, patch, pre, err, tt.major, tt.minor, tt.patch, tt.pre, tt.err)
+		}
+	}
+}
+
+var truncateTests = []struct {
+	in    string
+	level Level
+	out   string
+}{
+	{"go1.21.3", Major, "go1"},
+	{"go1.21.3", Minor, "go1.21"},
+	{"go1.21.3", Patch, "go1.21.3"},
+	{"go1.21.3-bigcorp", Patch, "go1.21.3"},
+	{"go1.9.2rc2", Patch, "go1.9.2"},
+	{"go1.9.2rc2", Minor, "go1.9"},
+	{"go1.21rc2", Patch, "go1.21"},
+	{"go1.20rc2", Patch, "go1.20"},
+	{"go1.20", Patch, "go1.20"},
+	{"go1.21", Patch, "go1.21"},
+	{"go1", Minor, "go1"},
+	{"go2.3.4", Major, "go2"},
+	{"bad", Major, ""},
+	{"go1.21.3", Level(7), ""},
+}
+
+func TestTruncate(t *testing.T) {
+	for _, tt := range truncateTests {
+		if out := Truncate(tt.in, tt.level); out != tt.out {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.level, out, tt.out)
+		}
+	}
+	for _, tt := range langTests {
+		if out := Truncate(tt.in, Minor); out != tt.out {
+			t.Errorf("Truncate(%q, Minor) = %q, want Lang = %q", tt.in, out, tt.out)
+		}
+	}
+}
+
+func TestSetters(t *testing.T) {
+	base := MustParse("go1.21.3")
+	for _, tt := range []struct {
+		v    Version
+		want string
+	}{
+		{base.WithPatch("5"), "go1.21.5"},
+		{base.WithPatch(""), "go1.21"},
+		{MustParse("go1.22").WithKind("rc").WithPre("2"), "go1.22rc2"},
+		{MustParse("go1.9.2").WithKind("rc").WithPre("1"), "go1.9.2rc1"},
+		{MustParse("go1.22rc2").WithKind("beta"), "go1.22beta2"},
+		{MustParse("go1.22rc2").WithKind(""), "go1.22"},
+		{MustParse("go1.22rc2").WithPre(""), "go1.22rc"},
+		{MustParse("go1.22rc2").WithPatch("0").WithKind(""), "go1.22.0"},
+		{MustParse("go1.20.3").WithPatch(""), "go1.20"},
+		{MustParse("go1.20").WithKind("rc").WithPre("2"), "go1.20rc2"},
+		{MustParse("go1.22.0").WithKind("rc").WithPre("1"), "go1.22rc1"},
+		{MustParse("go1.20rc2").WithKind(""), "go1.20"},
+		{MustParse("go1.9.2rc1").WithKind(""), "go1.9.2"},
+	} {
+		if out := tt.v.Canonical(); out != tt.want {
+			t.Errorf("got %s, want %s", out, tt.want)
+		}
+		if want, err := Parse(tt.want); err == nil && tt.v != want {
+			t.Errorf("got %#v, want %#v", tt.v, want)
+		}
+	}
+	if base != MustParse("go1.21.3") {
+		t.Errorf("setters modified receiver: %v", base)
+	}
+
+	for name, f := range map[string]func(){
+		`WithPatch("03")`:      func() { base.WithPatch("03") },
+		`WithPatch("x")`:       func() { base.WithPatch("x") },
+		`WithKind("RC")`:       func() { base.WithKind("RC") },
+		`WithKind("rc1")`:      func() { base.WithKind("rc1") },
+		`WithPre("-1")`:        func() { MustParse("go1.22rc1").WithPre("-1") },
+		`WithPre without kind`: func() { base.WithPre("1") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
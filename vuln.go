@@ -0,0 +1,271 @@
+package gover
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// defaultVulnDB is the Go vulnerability database.
+const defaultVulnDB = "https://vuln.go.dev"
+
+// vulnModules are the modules under which the vulnerability database
+// records vulnerabilities in the Go distribution: the standard library
+// and the go command and other tools.
+var vulnModules = []string{"stdlib", "toolchain"}
+
+// A Vuln is a vulnerability in the Go standard library or toolchain,
+// as recorded in the Go vulnerability database.
+type Vuln struct {
+	ID      string   // database identifier, such as "GO-2023-2102"
+	Aliases []string // other identifiers, such as "CVE-2023-39325"
+	Summary string   // one-line description
+	Fixed   string   // first version fixing the vulnerability, such as "go1.21.3"
+}
+
+// osvEntry is the part of an OSV vulnerability entry used by the client.
+// See https://ossf.github.io/osv-schema/.
+type osvEntry struct {
+	ID       string   `json:"id"`
+	Summary  string   `json:"summary"`
+	Aliases  []string `json:"aliases"`
+	Affected []struct {
+		Package struct {
+			Name string `json:"name"`
+		} `json:"package"`
+		Ranges []struct {
+			Type   string `json:"type"`
+			Events []struct {
+				Introduced string `json:"introduced"`
+				Fixed      string `json:"fixed"`
+			} `json:"events"`
+		} `json:"ranges"`
+	} `json:"affected"`
+}
+
+// affects reports whether the entry describes a vulnerability
+// in the Go distribution that affects the version v.
+// If so, it also returns the first version after v that is not affected,
+// or the zero Version if the vulnerability is not fixed in v's line of releases.
+func (e *osvEntry) affects(v Version) (affected bool, fixed Version) {
+	for _, a := range e.Affected {
+		if !slices.Contains(vulnModules, a.Package.Name) {
+			continue
+		}
+		for _, r := range a.Ranges {
+			if r.Type != "SEMVER" {
+				continue
+			}
+			in := false // whether v is in the current range
+			for _, ev := range r.Events {
+				switch {
+				case ev.Introduced != "":
+					in = ev.Introduced == "0" || fromOSVVersion(ev.Introduced).Compare(v) <= 0
+				case ev.Fixed != "":
+					f := fromOSVVersion(ev.Fixed)
+					if in && v.Compare(f) < 0 {
+						return true, f
+					}
+					in = false
+				}
+			}
+			if in {
+				return true, Version{}
+			}
+		}
+	}
+	return false, Version{}
+}
+
+// fromOSVVersion converts a version in the vulnerability database,
+// which uses semantic versions such as "1.21.2" and "1.21.0-rc.2",
+// to a Go version such as go1.21.2 or go1.21rc2.
+// The version "1.21.0-0", which precedes all prereleases of 1.21.0,
+// becomes the start of the go1.21 series, {Major: "1", Minor: "21"},
+// which precedes all go1.21 versions.
+// Invalid versions convert to the zero Version.
+func fromOSVVersion(s string) Version {
+	base, pre, _ := strings.Cut(s, "-")
+	if pre == "0" {
+		if x, ok := strings.CutSuffix(base, ".0"); ok {
+			v := parse(x)
+			return Version{Major: v.Major, Minor: v.Minor}
+		}
+	}
+	if pre != "" {
+		base = strings.TrimSuffix(base, ".0")
+		pre = strings.ReplaceAll(pre, ".", "")
+	}
+	return parse(base + pre)
+}
+
+// FixedVulns returns the vulnerabilities in the Go standard library and
+// toolchain that affect the version from but not the version to,
+// according to the Go vulnerability database: what upgrading from one to
+// the other fixes. The result is sorted by ID.
+// Each Vuln's Fixed field is the first version after from
+// that is not affected.
+//
+// FixedVulns fetches an entry from the database for each vulnerability
+// that might affect from, so it can make many requests.
+// It returns an error if the Client is Offline.
+func (c *Client) FixedVulns(ctx context.Context, from, to string) ([]Vuln, error) {
+	for _, v := range []string{from, to} {
+		if !IsValid(v) {
+			return nil, fmt.Errorf("invalid version %q", v)
+		}
+	}
+	entries, err := c.vulnEntries(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	vfrom, vto := parse(stripGo(from)), parse(stripGo(to))
+	var vulns []Vuln
+	for _, e := range entries {
+		fromAffected, fixed := e.affects(vfrom)
+		if toAffected, _ := e.affects(vto); fromAffected && !toAffected {
+			vulns = append(vulns, Vuln{ID: e.ID, Aliases: e.Aliases, Summary: e.Summary, Fixed: fixed.Canonical()})
+		}
+	}
+	return vulns, nil
+}
+
+// ToolchainVulns returns the vulnerabilities in the Go standard library
+// and toolchain that affect the toolchain version v, sorted by ID,
+// along with the first version in v's line of releases that fixes them all,
+// the minimal upgrade. The version v may be in any
+// form accepted by ParseRuntime, such as a toolchain version recorded in a
+// binary ("go1.21.0 X:loopvar"). Each Vuln's Fixed field is the first
+// version after v that is not affected by it.
+//
+// If no vulnerabilities affect v, upgrade is v, without any experiments. If some vulnerability
+// has no fix in v's line of releases, upgrade is the empty string:
+// fixing it requires moving to a newer language version.
+// ToolchainVulns returns an error for development toolchains,
+// which the vulnerability database does not describe.
+func (c *Client) ToolchainVulns(ctx context.Context, v string) (vulns []Vuln, upgrade string, err error) {
+	rv, err := ParseRuntime(v)
+	if err != nil {
+		return nil, "", err
+	}
+	if rv.Devel {
+		return nil, "", fmt.Errorf("cannot check development toolchain %q", v)
+	}
+	rv = Version{Major: rv.Major, Minor: rv.Minor, Patch: rv.Patch, Kind: rv.Kind, Pre: rv.Pre}
+	entries, err := c.vulnEntries(ctx, rv.String())
+	if err != nil {
+		return nil, "", err
+	}
+	for _, e := range entries {
+		if affected, fixed := e.affects(rv); affected {
+			vulns = append(vulns, Vuln{ID: e.ID, Aliases: e.Aliases, Summary: e.Summary, Fixed: fixed.Canonical()})
+		}
+	}
+
+	// Move the upgrade target past each vulnerability affecting it,
+	// until no vulnerability affects it.
+	target := rv
+	for moved := true; moved; {
+		moved = false
+		for _, e := range entries {
+			affected, fixed := e.affects(target)
+			if !affected {
+				continue
+			}
+			if fixed == (Version{}) {
+				return vulns, "", nil
+			}
+			target, moved = fixed, true
+		}
+	}
+	return vulns, target.Canonical(), nil
+}
+
+// RuntimeVulns is like ToolchainVulns for the running toolchain,
+// as reported by [runtime.Version].
+func (c *Client) RuntimeVulns(ctx context.Context) (vulns []Vuln, upgrade string, err error) {
+	return c.ToolchainVulns(ctx, runtimeVersion())
+}
+
+// vulnEntries returns the database entries for the vulnerabilities
+// in the Go distribution that might affect the version v, sorted by ID.
+// Entries for vulnerabilities fixed in every line of releases
+// before v are omitted.
+func (c *Client) vulnEntries(ctx context.Context, v string) ([]*osvEntry, error) {
+	if c.Offline {
+		return nil, errors.New("vulnerability database not available offline")
+	}
+	base := strings.TrimSuffix(cmp.Or(c.VulnDBURL, os.Getenv("GOVULNDB"), defaultVulnDB), "/")
+
+	var index []struct {
+		Path  string `json:"path"`
+		Vulns []struct {
+			ID    string `json:"id"`
+			Fixed string `json:"fixed"` // latest fixed version, if any
+		} `json:"vulns"`
+	}
+	if err := c.getJSON(ctx, base+"/index/modules.json", &index); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, m := range index {
+		if !slices.Contains(vulnModules, m.Path) {
+			continue
+		}
+		for _, e := range m.Vulns {
+			if e.Fixed == "" || parse(stripGo(v)).Compare(fromOSVVersion(e.Fixed)) < 0 {
+				ids = append(ids, e.ID)
+			}
+		}
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	// Fetch the entries a few at a time.
+	entries := make([]*osvEntry, len(ids))
+	errs := make([]error, len(ids))
+	sem := make(chan struct{}, 8)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			entries[i] = new(osvEntry)
+			errs[i] = c.getJSON(ctx, base+"/ID/"+id+".json", entries[i])
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// getJSON fetches url and decodes the JSON response into v.
+func (c *Client) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("fetching %s: %v", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package gover
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var vulnDB = map[string]string{
+	"/index/modules.json": `[
+		{"path": "golang.org/x/net", "vulns": [{"id": "GO-2023-2102", "fixed": "0.17.0"}]},
+		{"path": "stdlib", "vulns": [
+			{"id": "GO-2022-0001", "fixed": "1.17.3"},
+			{"id": "GO-2023-2041", "fixed": "1.21.1"},
+			{"id": "GO-2023-2102", "fixed": "1.21.3"}
+		]},
+		{"path": "toolchain", "vulns": [{"id": "GO-2099-0001"}]}
+	]`,
+	"/ID/GO-2023-2041.json": `{
+		"id": "GO-2023-2041",
+		"summary": "Improper handling of HTML-like comments in html/template",
+		"aliases": ["CVE-2023-39318"],
+		"affected": [{"package": {"name": "stdlib"}, "ranges": [{"type": "SEMVER", "events": [
+			{"introduced": "0"}, {"fixed": "1.20.8"}, {"introduced": "1.21.0-0"}, {"fixed": "1.21.1"}
+		]}]}]
+	}`,
+	"/ID/GO-2023-2102.json": `{
+		"id": "GO-2023-2102",
+		"summary": "HTTP/2 rapid reset can cause excessive work in net/http",
+		"aliases": ["CVE-2023-39325", "GHSA-4374-p667-p6c8"],
+		"affected": [
+			{"package": {"name": "golang.org/x/net"}, "ranges": [{"type": "SEMVER", "events": [
+				{"introduced": "0"}, {"fixed": "0.17.0"}
+			]}]},
+			{"package": {"name": "stdlib"}, "ranges": [{"type": "SEMVER", "events": [
+				{"introduced": "0"}, {"fixed": "1.20.10"}, {"introduced": "1.21.0-0"}, {"fixed": "1.21.3"}
+			]}]}
+		]
+	}`,
+	"/ID/GO-2099-0001.json": `{
+		"id": "GO-2099-0001",
+		"summary": "Unfixed vulnerability in cmd/go",
+		"affected": [{"package": {"name": "toolchain"}, "ranges": [{"type": "SEMVER", "events": [
+			{"introduced": "1.22.0"}
+		]}]}]
+	}`,
+}
+
+// newVulnClient returns a Client using a test server that serves db
+// as the vulnerability database.
+func newVulnClient(t *testing.T, db map[string]string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := db[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(data))
+	}))
+	t.Cleanup(srv.Close)
+	return &Client{HTTPClient: srv.Client(), CacheDir: "off", VulnDBURL: srv.URL + "/"}
+}
+
+var (
+	vulnHTMLComments = Vuln{
+		ID:      "GO-2023-2041",
+		Aliases: []string{"CVE-2023-39318"},
+		Summary: "Improper handling of HTML-like comments in html/template",
+		Fixed:   "go1.21.1",
+	}
+	vulnRapidReset = Vuln{
+		ID:      "GO-2023-2102",
+		Aliases: []string{"CVE-2023-39325", "GHSA-4374-p667-p6c8"},
+		Summary: "HTTP/2 rapid reset can cause excessive work in net/http",
+		Fixed:   "go1.21.3",
+	}
+)
+
+func withFixed(v Vuln, fixed string) Vuln {
+	v.Fixed = fixed
+	return v
+}
+
+var fixedVulnsTests = []struct {
+	from, to string
+	out      []Vuln
+}{
+	{"go1.21.0", "go1.21.3", []Vuln{vulnHTMLComments, vulnRapidReset}},
+	{"go1.21rc2", "go1.21.5", []Vuln{vulnHTMLComments, vulnRapidReset}},
+	{"go1.21.1", "go1.21.3", []Vuln{vulnRapidReset}},
+	{"go1.20.9", "go1.21.3", []Vuln{withFixed(vulnRapidReset, "go1.20.10")}},
+	{"go1.20.7", "go1.20.10", []Vuln{withFixed(vulnHTMLComments, "go1.20.8"), withFixed(vulnRapidReset, "go1.20.10")}},
+	{"go1.21.3", "go1.21.0", nil},
+	{"go1.21.0", "go1.21.2", []Vuln{vulnHTMLComments}},
+	{"go1.22.0", "go1.23.0", nil},
+}
+
+func TestFixedVulns(t *testing.T) {
+	c := newVulnClient(t, vulnDB)
+	ctx := context.Background()
+	for _, tt := range fixedVulnsTests {
+		out, err := c.FixedVulns(ctx, tt.from, tt.to)
+		if err != nil {
+			t.Errorf("FixedVulns(%q, %q): %v", tt.from, tt.to, err)
+			continue
+		}
+		if !reflect.DeepEqual(out, tt.out) {
+			t.Errorf("FixedVulns(%q, %q) = %+v, want %+v", tt.from, tt.to, out, tt.out)
+		}
+	}
+
+	// Old versions need the entry for GO-2022-0001, which is missing.
+	if out, err := c.FixedVulns(ctx, "go1.17.2", "go1.21.3"); err == nil || !strings.Contains(err.Error(), "GO-2022-0001") {
+		t.Errorf("FixedVulns(go1.17.2, go1.21.3) = %v, %v, want error fetching GO-2022-0001", out, err)
+	}
+	if out, err := c.FixedVulns(ctx, "go1.21", "1.21.3"); err == nil {
+		t.Errorf("FixedVulns with invalid version = %v, nil, want error", out)
+	}
+	c.Offline = true
+	if out, err := c.FixedVulns(ctx, "go1.21.0", "go1.21.3"); err == nil {
+		t.Errorf("offline FixedVulns = %v, nil, want error", out)
+	}
+}
+
+var fromOSVVersionTests = []struct {
+	in  string
+	out Version
+}{
+	{"1.21.2", Version{Major: "1", Minor: "21", Patch: "2"}},
+	{"1.20.0", Version{Major: "1", Minor: "20", Patch: "0"}},
+	{"1.21.0-rc.2", Version{Major: "1", Minor: "21", Kind: "rc", Pre: "2"}},
+	{"1.9.2-rc.2", Version{Major: "1", Minor: "9", Patch: "2", Kind: "rc", Pre: "2"}},
+	{"1.21.0-0", Version{Major: "1", Minor: "21"}},
+	{"1.20.0-0", Version{Major: "1", Minor: "20"}},
+	{"bad", Version{}},
+}
+
+func TestFromOSVVersion(t *testing.T) {
+	for _, tt := range fromOSVVersionTests {
+		if out := fromOSVVersion(tt.in); out != tt.out {
+			t.Errorf("fromOSVVersion(%q) = %#v, want %#v", tt.in, out, tt.out)
+		}
+	}
+}
+
+var vulnUnfixed = Vuln{ID: "GO-2099-0001", Summary: "Unfixed vulnerability in cmd/go"}
+
+var toolchainVulnsTests = []struct {
+	v       string
+	vulns   []Vuln
+	upgrade string
+}{
+	{"go1.21.0", []Vuln{vulnHTMLComments, vulnRapidReset}, "go1.21.3"},
+	{"go1.21.2", []Vuln{vulnRapidReset}, "go1.21.3"},
+	{"go1.20.9 X:loopvar", []Vuln{withFixed(vulnRapidReset, "go1.20.10")}, "go1.20.10"},
+	{"go1.21.3", nil, "go1.21.3"},
+	{"go1.20", []Vuln{withFixed(vulnHTMLComments, "go1.20.8"), withFixed(vulnRapidReset, "go1.20.10")}, "go1.20.10"},
+	{"go1.22.1", []Vuln{vulnUnfixed}, ""},
+}
+
+func TestToolchainVulns(t *testing.T) {
+	c := newVulnClient(t, vulnDB)
+	ctx := context.Background()
+	for _, tt := range toolchainVulnsTests {
+		vulns, upgrade, err := c.ToolchainVulns(ctx, tt.v)
+		if err != nil {
+			t.Errorf("ToolchainVulns(%q): %v", tt.v, err)
+			continue
+		}
+		if !reflect.DeepEqual(vulns, tt.vulns) || upgrade != tt.upgrade {
+			t.Errorf("ToolchainVulns(%q) = %+v, %q, want %+v, %q", tt.v, vulns, upgrade, tt.vulns, tt.upgrade)
+		}
+	}
+	for _, v := range []string{"devel go1.23-2b1f1e8 Thu Jan 4 20:00:00 2024 +0000", "1.21.0"} {
+		if vulns, upgrade, err := c.ToolchainVulns(ctx, v); err == nil {
+			t.Errorf("ToolchainVulns(%q) = %v, %q, nil, want error", v, vulns, upgrade)
+		}
+	}
+
+	defer func(f func() string) { runtimeVersion = f }(runtimeVersion)
+	runtimeVersion = func() string { return "go1.21.2" }
+	if vulns, upgrade, err := c.RuntimeVulns(ctx); err != nil || !reflect.DeepEqual(vulns, []Vuln{vulnRapidReset}) || upgrade != "go1.21.3" {
+		t.Errorf("RuntimeVulns() = %+v, %q, %v, want [%s], go1.21.3, nil", vulns, upgrade, err, vulnRapidReset.ID)
+	}
+}
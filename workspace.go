@@ -0,0 +1,98 @@
+package gover
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+)
+
+// A WorkspaceMember is a module used by a go.work file.
+type WorkspaceMember struct {
+	Dir  string // directory of the module, as listed in the use directive
+	Path string // module path
+	Go   string // go directive, such as "go1.21", or "" if none
+}
+
+// A WorkspaceReport describes how the go directive of a go.work file
+// relates to those of its member modules; see [CheckWorkspace].
+type WorkspaceReport struct {
+	Go       string            // go directive of the go.work file, or "" if none
+	Members  []WorkspaceMember // members, in the order of the go.work file
+	Required string            // maximum go directive of the members, or "" if none
+	Behind   []WorkspaceMember // members whose go directive is newer than Go
+	Fix      string            // how to fix the go.work file, or "" if consistent
+}
+
+// OK reports whether the workspace's go directive is at least
+// the go directive of every member.
+func (r *WorkspaceReport) OK() bool {
+	return len(r.Behind) == 0
+}
+
+// CheckWorkspace loads the go.work file path and the go.mod file of each
+// module it uses, and reports whether the workspace's go directive is at
+// least every member's go directive, as the go command requires.
+// Otherwise the go command refuses to build in the workspace, with errors
+// that name only one member at a time; the report lists all of them,
+// along with the minimal fix, raising the workspace's go directive to the
+// members' maximum, which is what "go work use" does.
+// CheckWorkspace returns an error if a file cannot be read or parsed.
+func CheckWorkspace(path string) (*WorkspaceReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	wf, err := modfile.ParseWork(path, data, keepVersion)
+	if err != nil {
+		return nil, err
+	}
+	r := new(WorkspaceReport)
+	if r.Go, _, err = directives(wf.Go, nil); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	// A go.work file without a go directive is treated as saying go 1.18.
+	effective := cmp.Or(r.Go, "go1.18")
+	for _, use := range wf.Use {
+		dir := use.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(path), dir)
+		}
+		gomod := filepath.Join(dir, "go.mod")
+		data, err := os.ReadFile(gomod)
+		if err != nil {
+			return nil, err
+		}
+		mf, err := modfile.ParseLax(gomod, data, keepVersion)
+		if err != nil {
+			return nil, err
+		}
+		m := WorkspaceMember{Dir: use.Path}
+		if mf.Module != nil {
+			m.Path = mf.Module.Mod.Path
+		}
+		if m.Go, _, err = directives(mf.Go, nil); err != nil {
+			return nil, fmt.Errorf("%s: %v", gomod, err)
+		}
+		r.Members = append(r.Members, m)
+		if m.Go == "" {
+			continue
+		}
+		if r.Required == "" || Compare(m.Go, r.Required) > 0 {
+			r.Required = m.Go
+		}
+		if Compare(m.Go, effective) > 0 {
+			r.Behind = append(r.Behind, m)
+		}
+	}
+	switch {
+	case r.OK():
+	case r.Go == "":
+		r.Fix = fmt.Sprintf("add go %s to go.work, with go work edit -go=%s or go work use", stripGo(r.Required), stripGo(r.Required))
+	default:
+		r.Fix = fmt.Sprintf("raise go.work's go directive from %s to %s, with go work edit -go=%s or go work use", stripGo(r.Go), stripGo(r.Required), stripGo(r.Required))
+	}
+	return r, nil
+}
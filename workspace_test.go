@@ -0,0 +1,64 @@
+package gover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFiles writes the files, given as a map from slash-separated file name
+// to contents, into dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, data := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(data), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCheckWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.work":      "go 1.21\n\nuse (\n\t./a\n\t./b\n\t./c\n\t./d\n)\n",
+		"a/go.mod":     "module example.com/a\n\ngo 1.20\n",
+		"b/go.mod":     "module example.com/b\n\ngo 1.22.3\n",
+		"c/go.mod":     "module example.com/c\n\ngo 1.22\n",
+		"d/go.mod":     "module example.com/d\n",
+		"ok/go.work":   "go 1.22.3\n\nuse ../a\nuse ../b\n",
+		"none/go.work": "use ../a\nuse ../b\n",
+		"bad/go.work":  "go 1.22\nuse ../missing\n",
+	})
+
+	r, err := CheckWorkspace(filepath.Join(dir, "go.work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Go != "go1.21" || r.Required != "go1.22.3" || r.OK() || len(r.Members) != 4 {
+		t.Errorf("CheckWorkspace: Go=%q Required=%q OK=%v members=%v", r.Go, r.Required, r.OK(), r.Members)
+	}
+	if len(r.Behind) != 2 || r.Behind[0].Path != "example.com/b" || r.Behind[1] != (WorkspaceMember{"./c", "example.com/c", "go1.22"}) {
+		t.Errorf("CheckWorkspace: Behind = %v, want b and c", r.Behind)
+	}
+	if want := "raise go.work's go directive from 1.21 to 1.22.3, with go work edit -go=1.22.3 or go work use"; r.Fix != want {
+		t.Errorf("CheckWorkspace: Fix = %q, want %q", r.Fix, want)
+	}
+
+	r, err = CheckWorkspace(filepath.Join(dir, "ok", "go.work"))
+	if err != nil || !r.OK() || r.Fix != "" || r.Required != "go1.22.3" {
+		t.Errorf("CheckWorkspace(ok) = %+v, %v, want consistent", r, err)
+	}
+
+	r, err = CheckWorkspace(filepath.Join(dir, "none", "go.work"))
+	if err != nil || r.OK() || r.Go != "" || len(r.Behind) != 2 || r.Fix == "" {
+		t.Errorf("CheckWorkspace(no go directive) = %+v, %v, want a and b behind", r, err)
+	}
+
+	if _, err := CheckWorkspace(filepath.Join(dir, "bad", "go.work")); err == nil {
+		t.Errorf("CheckWorkspace with missing member succeeded")
+	}
+}